@@ -0,0 +1,86 @@
+package engine
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Any binds a whole slice as one Postgres array parameter and renders
+// = ANY($n). A single array parameter keeps the statement text identical for
+// any number of values, which is friendlier to plan caching than an expanded
+// IN list. Other dialects have no array parameters and return an error; use
+// bindSlice there.
+func (parser *SqlParser) Any(arg any) (string, error) {
+	if parser.dialect != Postgres {
+		return "", fmt.Errorf("array parameters are not supported by %s, use bindSlice", parser.dialect)
+	}
+
+	value := reflect.ValueOf(arg)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return "", fmt.Errorf("expected slice or array, got %T", arg)
+	}
+
+	return "= ANY(" + parser.Parse(pgArray{value: value}) + ")", nil
+}
+
+// pgArray encodes a slice in the Postgres array text format, the same way
+// pq.Array does, so it binds with lib/pq and pgx alike.
+type pgArray struct {
+	value reflect.Value
+}
+
+func (array pgArray) Value() (driver.Value, error) {
+	return encodeArray(array.value)
+}
+
+func encodeArray(value reflect.Value) (string, error) {
+	elems := make([]string, value.Len())
+	for i := range elems {
+		elem, err := encodeElem(value.Index(i))
+		if err != nil {
+			return "", err
+		}
+
+		elems[i] = elem
+	}
+
+	return "{" + strings.Join(elems, ",") + "}", nil
+}
+
+func encodeElem(value reflect.Value) (string, error) {
+	if value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return "NULL", nil
+		}
+		return encodeElem(value.Elem())
+	}
+
+	switch elem := value.Interface().(type) {
+	case time.Time:
+		return quoteElem(elem.Format(time.RFC3339Nano)), nil
+	case []byte:
+		return "", fmt.Errorf("cannot encode []byte array elements")
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return quoteElem(value.String()), nil
+	case reflect.Bool:
+		return fmt.Sprint(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(value.Interface()), nil
+	case reflect.Slice, reflect.Array:
+		return encodeArray(value)
+	}
+
+	return "", fmt.Errorf("cannot encode %s array elements", value.Type())
+}
+
+func quoteElem(elem string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(elem) + `"`
+}
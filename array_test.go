@@ -0,0 +1,43 @@
+package engine
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestAny(t *testing.T) {
+	const query = "SELECT * FROM users WHERE id {{ any .IDs }}"
+
+	sql, args, err := ExecuteE(query, map[string]any{"IDs": []int64{1, 2, 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "SELECT * FROM users WHERE id = ANY($1)"; sql != want {
+		t.Errorf("got %q, want %q", sql, want)
+	}
+	if len(args) != 1 {
+		t.Fatalf("got args %v, want one", args)
+	}
+
+	valuer, ok := args[0].(driver.Valuer)
+	if !ok {
+		t.Fatalf("bound %T, want a driver.Valuer", args[0])
+	}
+	value, err := valuer.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "{1,2,3}"; value != want {
+		t.Errorf("encoded %q, want %q", value, want)
+	}
+
+	strs, _ := pgArray{value: reflect.ValueOf([]string{`a"b`, "c"})}.Value()
+	if want := `{"a\"b","c"}`; strs != want {
+		t.Errorf("encoded %q, want %q", strs, want)
+	}
+
+	if _, _, err := NewEngine(WithDialect(MySQL)).Execute(query, map[string]any{"IDs": []int64{1}}); err == nil {
+		t.Error("array parameter on MySQL did not fail")
+	}
+}
@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+)
+
+// WithSharedPlaceholders makes ExecuteBatch continue placeholder numbering
+// from one query to the next, for statements sent with a single param list.
+// Each query still gets only the args it bound.
+func WithSharedPlaceholders(enabled bool) Option {
+	return func(engine *Engine) error {
+		engine.shared = enabled
+		return nil
+	}
+}
+
+// ExecuteBatch renders every query with the same args and returns the SQL and
+// bound args of each, in order.
+func ExecuteBatch(queries []string, args any) ([]string, [][]any, error) {
+	return defaultEngine.ExecuteBatch(queries, args)
+}
+
+func (engine *Engine) ExecuteBatch(queries []string, args any) ([]string, [][]any, error) {
+	sqls := make([]string, len(queries))
+	batchArgs := make([][]any, len(queries))
+
+	offset := 0
+	for i, query := range queries {
+		start := time.Now()
+		tmpl, err := engine.Compile(query)
+		if err != nil {
+			engine.observe(ExecInfo{Duration: time.Since(start), Err: err})
+			return nil, nil, fmt.Errorf("engine: batch query %d: %w", i, err)
+		}
+
+		var setup func(*SqlParser)
+		if engine.shared {
+			setup = func(parser *SqlParser) { parser.count += offset }
+		}
+
+		sql, sqlArgs, err := tmpl.run(args, setup)
+		if err != nil {
+			return nil, nil, fmt.Errorf("engine: batch query %d: %w", i, err)
+		}
+
+		sqls[i], batchArgs[i] = sql, sqlArgs
+		offset += len(sqlArgs)
+	}
+
+	return sqls, batchArgs, nil
+}
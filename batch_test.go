@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExecuteBatch(t *testing.T) {
+	queries := []string{
+		"INSERT INTO orders (id, sku) VALUES ({{ bind .ID }}, {{ bind .SKU }})",
+		"UPDATE stock SET qty = qty - 1 WHERE sku = {{ bind .SKU }}",
+	}
+	args := map[string]any{"ID": 1, "SKU": "a-1"}
+	wantArgs := [][]any{{1, "a-1"}, {"a-1"}}
+
+	tests := []struct {
+		name   string
+		shared bool
+		want   []string
+	}{
+		{"independent", false, []string{
+			"INSERT INTO orders (id, sku) VALUES ($1, $2)",
+			"UPDATE stock SET qty = qty - 1 WHERE sku = $1",
+		}},
+		{"shared", true, []string{
+			"INSERT INTO orders (id, sku) VALUES ($1, $2)",
+			"UPDATE stock SET qty = qty - 1 WHERE sku = $3",
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sqls, batchArgs, err := NewEngine(WithSharedPlaceholders(test.shared)).ExecuteBatch(queries, args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(sqls, test.want) {
+				t.Errorf("got %q, want %q", sqls, test.want)
+			}
+			if !reflect.DeepEqual(batchArgs, wantArgs) {
+				t.Errorf("got args %v, want %v", batchArgs, wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// Paginate renders the dialect's LIMIT/OFFSET clause with both values bound.
+// A limit of zero or less renders nothing. SQL Server and Oracle use
+// OFFSET ... FETCH NEXT, which SQL Server only accepts after an ORDER BY.
+func (parser *SqlParser) Paginate(limit, offset any) (string, error) {
+	rows, err := toInt(limit)
+	if err != nil {
+		return "", fmt.Errorf("limit: %w", err)
+	}
+
+	skip, err := toInt(offset)
+	if err != nil {
+		return "", fmt.Errorf("offset: %w", err)
+	}
+
+	if rows <= 0 {
+		return "", nil
+	}
+
+	if skip < 0 {
+		return "", fmt.Errorf("offset must not be negative, got %d", skip)
+	}
+
+	switch parser.dialect {
+	case SQLServer, Oracle:
+		skipped := parser.Parse(skip)
+		return fmt.Sprintf("OFFSET %s ROWS FETCH NEXT %s ROWS ONLY", skipped, parser.Parse(rows)), nil
+	}
+
+	limited := parser.Parse(rows)
+	return fmt.Sprintf("LIMIT %s OFFSET %s", limited, parser.Parse(skip)), nil
+}
+
+// Ident quotes a table or column name for the dialect after checking it is a
+// plain identifier, optionally qualified by a schema.
+func (parser *SqlParser) Ident(name string) (string, error) {
+	if !identifier.MatchString(name) {
+		return "", fmt.Errorf("invalid identifier %q", name)
+	}
+
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = parser.dialect.quote(part)
+	}
+
+	return strings.Join(parts, "."), nil
+}
+
+// OrderBy renders an ORDER BY clause for a user supplied sort column and
+// direction. The column must be one of allowed and the direction ASC or DESC
+// in any case; an empty direction means ASC.
+func (parser *SqlParser) OrderBy(col, dir string, allowed ...string) (string, error) {
+	if !slices.Contains(allowed, col) {
+		return "", fmt.Errorf("sort column %q is not allowed", col)
+	}
+
+	direction := strings.ToUpper(dir)
+	switch direction {
+	case "":
+		direction = "ASC"
+	case "ASC", "DESC":
+	default:
+		return "", fmt.Errorf("sort direction %q is not ASC or DESC", dir)
+	}
+
+	column, err := parser.Ident(col)
+	if err != nil {
+		return "", err
+	}
+
+	return "ORDER BY " + column + " " + direction, nil
+}
+
+// OnConflict renders the upsert clause of the dialect. conflict is a column
+// name, a comma separated list of them or a []string. With no update columns
+// Postgres renders DO NOTHING and MySQL a no-op update of the first conflict
+// column. Only Postgres and MySQL are supported.
+func (parser *SqlParser) OnConflict(conflict any, update ...string) (string, error) {
+	var targets []string
+	switch conflict := conflict.(type) {
+	case string:
+		for _, name := range strings.Split(conflict, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				targets = append(targets, name)
+			}
+		}
+	case []string:
+		targets = conflict
+	default:
+		return "", fmt.Errorf("conflict columns must be a string or []string, got %T", conflict)
+	}
+
+	targets, err := parser.idents(targets)
+	if err != nil {
+		return "", err
+	}
+
+	columns, err := parser.idents(update)
+	if err != nil {
+		return "", err
+	}
+
+	switch parser.dialect {
+	case Postgres:
+		target := ""
+		if len(targets) > 0 {
+			target = " (" + strings.Join(targets, ", ") + ")"
+		}
+
+		if len(columns) == 0 {
+			return "ON CONFLICT" + target + " DO NOTHING", nil
+		}
+
+		if target == "" {
+			return "", fmt.Errorf("DO UPDATE needs conflict columns")
+		}
+
+		sets := make([]string, len(columns))
+		for i, column := range columns {
+			sets[i] = column + " = EXCLUDED." + column
+		}
+
+		return "ON CONFLICT" + target + " DO UPDATE SET " + strings.Join(sets, ", "), nil
+	case MySQL:
+		if len(columns) == 0 {
+			if len(targets) == 0 {
+				return "", fmt.Errorf("a no-op update needs a conflict column")
+			}
+
+			return "ON DUPLICATE KEY UPDATE " + targets[0] + " = " + targets[0], nil
+		}
+
+		sets := make([]string, len(columns))
+		for i, column := range columns {
+			sets[i] = column + " = VALUES(" + column + ")"
+		}
+
+		return "ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", "), nil
+	}
+
+	return "", fmt.Errorf("not supported by %s", parser.dialect)
+}
+
+func (parser *SqlParser) idents(names []string) ([]string, error) {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		ident, err := parser.Ident(name)
+		if err != nil {
+			return nil, err
+		}
+
+		quoted[i] = ident
+	}
+
+	return quoted, nil
+}
+
+// Returning renders the clause that hands back columns of the written rows:
+// RETURNING on Postgres and OUTPUT INSERTED.* on SQL Server, where it must sit
+// before VALUES or WHERE. MySQL and Oracle have no equivalent and return an
+// error.
+func (parser *SqlParser) Returning(cols ...string) (string, error) {
+	if len(cols) == 0 {
+		return "", fmt.Errorf("no columns to return")
+	}
+
+	columns, err := parser.idents(cols)
+	if err != nil {
+		return "", err
+	}
+
+	switch parser.dialect {
+	case Postgres:
+		return "RETURNING " + strings.Join(columns, ", "), nil
+	case SQLServer:
+		for i, column := range columns {
+			columns[i] = "INSERTED." + column
+		}
+		return "OUTPUT " + strings.Join(columns, ", "), nil
+	}
+
+	return "", fmt.Errorf("not supported by %s", parser.dialect)
+}
+
+// Set renders the assignments of an UPDATE from column/value pairs, binding
+// every value.
+func (parser *SqlParser) Set(pairs ...any) (string, error) {
+	return parser.set(false, pairs)
+}
+
+// SetOptional is Set, except that pairs whose value is nil or a nil pointer
+// are left out, so optional fields are only updated when given.
+func (parser *SqlParser) SetOptional(pairs ...any) (string, error) {
+	return parser.set(true, pairs)
+}
+
+func (parser *SqlParser) set(optional bool, pairs []any) (string, error) {
+	if len(pairs)%2 != 0 {
+		return "", fmt.Errorf("expected column/value pairs, got %d arguments", len(pairs))
+	}
+
+	assignments := make([]string, 0, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		name, ok := pairs[i].(string)
+		if !ok {
+			return "", fmt.Errorf("column name must be a string, got %T", pairs[i])
+		}
+
+		column, err := parser.Ident(name)
+		if err != nil {
+			return "", err
+		}
+
+		if optional && parser.bindable(pairs[i+1]) == nil {
+			continue
+		}
+
+		assignments = append(assignments, column+" = "+parser.Parse(pairs[i+1]))
+	}
+
+	if len(assignments) == 0 {
+		return "", fmt.Errorf("no columns to set")
+	}
+
+	return strings.Join(assignments, ", "), nil
+}
+
+// BoolLit renders value as an inline boolean literal: TRUE/FALSE on Postgres
+// and 1/0 elsewhere. Use bind instead to pass a bool as a parameter.
+func (parser *SqlParser) BoolLit(value bool) string {
+	return parser.dialect.boolean(value)
+}
+
+// LikePrefix binds term, with its LIKE wildcards escaped, as a prefix pattern.
+func (parser *SqlParser) LikePrefix(term string) string {
+	return parser.like(parser.escapeLike(term) + "%")
+}
+
+// LikeContains binds term, with its LIKE wildcards escaped, as a substring
+// pattern.
+func (parser *SqlParser) LikeContains(term string) string {
+	return parser.like("%" + parser.escapeLike(term) + "%")
+}
+
+// like binds pattern and, for dialects without a default LIKE escape
+// character, declares the backslash as one.
+func (parser *SqlParser) like(pattern string) string {
+	placeholder := parser.Parse(pattern)
+
+	switch parser.dialect {
+	case SQLServer, Oracle:
+		return placeholder + ` ESCAPE '\'`
+	}
+
+	return placeholder
+}
+
+func (parser *SqlParser) escapeLike(term string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	if parser.dialect == SQLServer {
+		replacer = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`, "[", `\[`)
+	}
+
+	return replacer.Replace(term)
+}
+
+func toInt(arg any) (int64, error) {
+	value := reflect.ValueOf(arg)
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(value.Uint()), nil
+	}
+
+	return 0, fmt.Errorf("expected an integer, got %T", arg)
+}
@@ -0,0 +1,207 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	const query = "SELECT id FROM users ORDER BY id {{ paginate .PageSize .Offset }}"
+
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		pageSize int
+		wantSQL  string
+		wantArgs []any
+	}{
+		{"postgres", Postgres, 20, "SELECT id FROM users ORDER BY id LIMIT $1 OFFSET $2", []any{int64(20), int64(40)}},
+		{"sqlserver", SQLServer, 20, "SELECT id FROM users ORDER BY id OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY", []any{int64(40), int64(20)}},
+		{"oracle", Oracle, 20, "SELECT id FROM users ORDER BY id OFFSET :1 ROWS FETCH NEXT :2 ROWS ONLY", []any{int64(40), int64(20)}},
+		{"zero limit", Postgres, 0, "SELECT id FROM users ORDER BY id ", []any{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sql, args, err := NewEngine(WithDialect(test.dialect)).Execute(query, map[string]any{"PageSize": test.pageSize, "Offset": 40})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sql != test.wantSQL {
+				t.Errorf("got %q, want %q", sql, test.wantSQL)
+			}
+			if !reflect.DeepEqual(args, test.wantArgs) {
+				t.Errorf("got args %#v, want %#v", args, test.wantArgs)
+			}
+		})
+	}
+}
+
+func TestIdent(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		ident   string
+		want    string
+		wantErr bool
+	}{
+		{"postgres", Postgres, "orders_2024", `SELECT * FROM "orders_2024"`, false},
+		{"mysql", MySQL, "orders_2024", "SELECT * FROM `orders_2024`", false},
+		{"sqlserver", SQLServer, "orders_2024", "SELECT * FROM [orders_2024]", false},
+		{"schema qualified", Postgres, "tenant_7.orders", `SELECT * FROM "tenant_7"."orders"`, false},
+		{"quote", Postgres, `orders"; DROP TABLE users; --`, "", true},
+		{"two dots", Postgres, "a.b.c", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sql, _, err := NewEngine(WithDialect(test.dialect)).Execute("SELECT * FROM {{ ident .Table }}", map[string]any{"Table": test.ident})
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("invalid identifier rendered %q", sql)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sql != test.want {
+				t.Errorf("got %q, want %q", sql, test.want)
+			}
+		})
+	}
+}
+
+func TestLikeEscaping(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		fn       string
+		wantSQL  string
+		wantTerm string
+	}{
+		{"prefix", Postgres, "likePrefix", `WHERE name LIKE $1`, `50\%\_off%`},
+		{"contains", MySQL, "likeContains", `WHERE name LIKE ?`, `%50\%\_off%`},
+		{"sqlserver", SQLServer, "likePrefix", `WHERE name LIKE @p1 ESCAPE '\'`, `50\%\_off%`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sql, args, err := NewEngine(WithDialect(test.dialect)).Execute("WHERE name LIKE {{ "+test.fn+" .Term }}", map[string]any{"Term": "50%_off"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sql != test.wantSQL {
+				t.Errorf("got %q, want %q", sql, test.wantSQL)
+			}
+			if want := []any{test.wantTerm}; !reflect.DeepEqual(args, want) {
+				t.Errorf("got args %q, want %q", args, want)
+			}
+		})
+	}
+}
+
+func TestOrderBy(t *testing.T) {
+	const query = `SELECT * FROM users {{ orderBy .Sort .Dir "name" "created_at" }}`
+
+	sql, _, err := ExecuteE(query, map[string]any{"Sort": "created_at", "Dir": "desc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `SELECT * FROM users ORDER BY "created_at" DESC`; sql != want {
+		t.Errorf("got %q, want %q", sql, want)
+	}
+
+	rejected := []map[string]any{
+		{"Sort": "name; DROP TABLE users", "Dir": "ASC"},
+		{"Sort": "name", "Dir": "ASC; DROP TABLE users"},
+	}
+	for _, args := range rejected {
+		if sql, _, err := ExecuteE(query, args); err == nil {
+			t.Errorf("%v rendered %q", args, sql)
+		}
+	}
+}
+
+func TestOnConflict(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		query   string
+		want    string
+	}{
+		{"postgres update", Postgres, `{{ onConflict "email" "name" "updated_at" }}`, `ON CONFLICT ("email") DO UPDATE SET "name" = EXCLUDED."name", "updated_at" = EXCLUDED."updated_at"`},
+		{"postgres nothing", Postgres, `{{ onConflict "email" }}`, `ON CONFLICT ("email") DO NOTHING`},
+		{"mysql update", MySQL, `{{ onConflict "email" "name" }}`, "ON DUPLICATE KEY UPDATE `name` = VALUES(`name`)"},
+		{"mysql nothing", MySQL, `{{ onConflict "email" }}`, "ON DUPLICATE KEY UPDATE `email` = `email`"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sql, args, err := NewEngine(WithDialect(test.dialect)).Execute(test.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sql != test.want || len(args) != 0 {
+				t.Errorf("got %q with args %v, want %q", sql, args, test.want)
+			}
+		})
+	}
+}
+
+func TestReturning(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		want    string
+		wantErr bool
+	}{
+		{Postgres, `RETURNING "id", "created_at"`, false},
+		{SQLServer, `OUTPUT INSERTED.[id], INSERTED.[created_at]`, false},
+		{MySQL, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dialect.String(), func(t *testing.T) {
+			sql, _, err := NewEngine(WithDialect(test.dialect)).Execute(`{{ returning "id" "created_at" }}`, nil)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("rendered %q, want an error", sql)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sql != test.want {
+				t.Errorf("got %q, want %q", sql, test.want)
+			}
+		})
+	}
+}
+
+func TestBoolLit(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{Postgres, "WHERE active = TRUE AND deleted = FALSE AND verified = $1"},
+		{MySQL, "WHERE active = 1 AND deleted = 0 AND verified = ?"},
+		{SQLServer, "WHERE active = 1 AND deleted = 0 AND verified = @p1"},
+		{Oracle, "WHERE active = 1 AND deleted = 0 AND verified = :1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dialect.String(), func(t *testing.T) {
+			sql, args, err := NewEngine(WithDialect(test.dialect)).Execute(`WHERE active = {{ boolLit true }} AND deleted = {{ boolLit .Deleted }} AND verified = {{ bind .Verified }}`, map[string]any{"Deleted": false, "Verified": true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sql != test.want {
+				t.Errorf("got %q, want %q", sql, test.want)
+			}
+			if want := []any{true}; !reflect.DeepEqual(args, want) {
+				t.Errorf("got args %v, want %v", args, want)
+			}
+		})
+	}
+}
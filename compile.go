@@ -0,0 +1,241 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Template is a parsed query that can be run any number of times. It is safe
+// for concurrent use: every Run executes against its own parser.
+type Template struct {
+	engine *Engine
+	name   string
+}
+
+func Compile(query string) (*Template, error) {
+	return defaultEngine.Compile(query)
+}
+
+// maxCachedQueries bounds the templates an engine keeps compiled, so queries
+// built on the fly cannot grow the cache without limit.
+const maxCachedQueries = 1024
+
+// Compile parses query, or returns the template compiled from the same query
+// text earlier. Templates are immutable, so sharing them is safe, and reusing
+// one also reuses the runs pooled by its previous executions.
+func (engine *Engine) Compile(query string) (*Template, error) {
+	if tmpl, ok := engine.compiled.Load(query); ok {
+		return tmpl.(*Template), nil
+	}
+
+	tmpl, err := engine.compile("", query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Concurrent misses can overshoot the bound slightly, which is harmless.
+	if engine.cached.Load() < maxCachedQueries {
+		if cached, loaded := engine.compiled.LoadOrStore(query, tmpl); loaded {
+			return cached.(*Template), nil
+		}
+		engine.cached.Add(1)
+	}
+
+	return tmpl, nil
+}
+
+// compile parses query as the root template, or as a template of its own when
+// named, so errors and observers can report the name.
+func (engine *Engine) compile(name string, query string) (*Template, error) {
+	compiled, err := engine.fork()
+	if err != nil {
+		return nil, fmt.Errorf("engine: parse: %w", err)
+	}
+
+	tmpl := compiled.tempalte
+	if name != "" {
+		tmpl = tmpl.New(name)
+	}
+
+	if _, err := tmpl.Parse(query); err != nil {
+		return nil, parseError(err)
+	}
+
+	if err := compiled.prepare(); err != nil {
+		return nil, err
+	}
+
+	return &Template{engine: compiled, name: name}, nil
+}
+
+// prepare checks and rewrites the freshly parsed templates of engine before
+// their first run.
+func (engine *Engine) prepare() error {
+	if engine.strict {
+		if err := engine.checkStrict(); err != nil {
+			return err
+		}
+	}
+
+	engine.bindNames()
+	if engine.track {
+		engine.trackSites()
+	}
+
+	return nil
+}
+
+func (t *Template) Run(args any) (string, []any, error) {
+	return t.run(args, nil)
+}
+
+// RunContext is Run unless ctx is already done, and fails with the context's
+// error if ctx is done by the time rendering finishes.
+func (t *Template) RunContext(ctx context.Context, args any) (string, []any, error) {
+	return t.runContext(ctx, args, nil)
+}
+
+// Prepared is Run without the args, see ExecutePrepared.
+func (t *Template) Prepared(args any) (string, error) {
+	sql, _, err := t.Run(args)
+	return sql, err
+}
+
+func (t *Template) RunTo(w io.Writer, args any) ([]any, error) {
+	return t.runTo(w, args, nil)
+}
+
+func (t *Template) run(args any, setup func(*SqlParser)) (string, []any, error) {
+	return t.runContext(context.Background(), args, setup)
+}
+
+func (t *Template) runContext(ctx context.Context, args any, setup func(*SqlParser)) (string, []any, error) {
+	out := getBuffer()
+	defer putBuffer(out)
+
+	sqlArgs, err := t.engine.render(ctx, out, t.name, args, setup)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return out.String(), sqlArgs, nil
+}
+
+func (t *Template) runTo(w io.Writer, args any, setup func(*SqlParser)) ([]any, error) {
+	return t.engine.render(context.Background(), w, t.name, args, setup)
+}
+
+// render executes the named template, or the root one when name is empty,
+// into w. Rewrites and observers need the whole query, so when any are
+// configured the output is buffered before being written. A ctx done before
+// or during rendering fails it with the context's error.
+func (engine *Engine) render(ctx context.Context, w io.Writer, name string, args any, setup func(*SqlParser)) ([]any, error) {
+	start := time.Now()
+	if err := ctx.Err(); err != nil {
+		engine.observe(ExecInfo{Name: name, Duration: time.Since(start), Err: err})
+		return nil, err
+	}
+
+	if len(engine.rewrites) == 0 && engine.observer == nil {
+		sqlArgs, err := engine.execute(w, name, args, setup)
+		if err == nil {
+			err = ctx.Err()
+		}
+		if err != nil {
+			return nil, err
+		}
+		return sqlArgs, nil
+	}
+
+	out := getBuffer()
+	defer putBuffer(out)
+
+	// ExecuteNamed binds into a map instead of args, so keep hold of it to
+	// report its size.
+	var named map[string]any
+	sqlArgs, err := engine.execute(out, name, args, func(parser *SqlParser) {
+		if setup != nil {
+			setup(parser)
+		}
+		named = parser.named
+	})
+	if err == nil {
+		err = ctx.Err()
+	}
+
+	sql := ""
+	if err == nil {
+		sql = out.String()
+		for _, rewrite := range engine.rewrites {
+			sql = rewrite(sql)
+		}
+	}
+
+	engine.observe(ExecInfo{Name: name, SQL: sql, Args: len(sqlArgs) + len(named), Duration: time.Since(start), Err: err})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.WriteString(w, sql); err != nil {
+		return nil, fmt.Errorf("engine: write: %w", err)
+	}
+
+	return sqlArgs, nil
+}
+
+// execute runs the template on a fork of the engine so the args it collects
+// belong to this execution alone. Forks are pooled; the returned args are a
+// copy, so the fork's args slice can be reused by the next execution.
+//
+// text/template already turns panics in template functions into errors that
+// name the function. Any panic that still escapes, for instance from w, is
+// recovered into an error as well, and the fork is dropped rather than pooled
+// since its parser may be half written.
+func (engine *Engine) execute(w io.Writer, name string, args any, setup func(*SqlParser)) (sqlArgs []any, err error) {
+	run, err := engine.acquire()
+	if err != nil {
+		return nil, fmt.Errorf("engine: execute: %w", err)
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			sqlArgs, err = nil, fmt.Errorf("engine: execute: panic: %v", recovered)
+			return
+		}
+		engine.release(run)
+	}()
+
+	run.parser.data = args
+	if setup != nil {
+		setup(run.parser)
+	}
+
+	buf, _ := w.(*bytes.Buffer)
+	written := 0
+	if buf != nil {
+		written = buf.Len()
+	}
+
+	if name == "" {
+		err = run.tempalte.Execute(w, args)
+	} else {
+		err = run.tempalte.ExecuteTemplate(w, name, args)
+	}
+
+	if err != nil {
+		var partial []byte
+		if buf != nil {
+			partial = buf.Bytes()[written:]
+		}
+		return nil, execError(err, partial)
+	}
+
+	if run.parser.err != nil {
+		return nil, fmt.Errorf("engine: execute: %w", run.parser.err)
+	}
+
+	return append(make([]any, 0, len(run.parser.args)), run.parser.args...), nil
+}
@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"text/template"
+)
+
+func TestTemplateRunConcurrent(t *testing.T) {
+	tmpl, err := Compile(`SELECT * FROM users WHERE id = {{ bind .ID }} AND name = {{ bind .Name }}{{ if .Tags }} AND tag IN ({{ bindSlice .Tags }}){{ end }}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			tags := make([]string, i%4)
+			for j := range tags {
+				tags[j] = fmt.Sprintf("tag-%d-%d", i, j)
+			}
+
+			for n := 0; n < 20; n++ {
+				_, args, err := tmpl.Run(map[string]any{"ID": i, "Name": fmt.Sprint("user-", i), "Tags": tags})
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				want := []any{i, fmt.Sprint("user-", i)}
+				for _, tag := range tags {
+					want = append(want, tag)
+				}
+				if !reflect.DeepEqual(args, want) {
+					t.Errorf("goroutine %d: got args %v, want %v", i, args, want)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestRegistrySubConcurrent(t *testing.T) {
+	registry := NewRegistry(nil)
+	registry.MustRegister("active_users", `SELECT id FROM users WHERE org_id = {{ bind .Org }}`)
+	registry.MustRegister("orders", `SELECT * FROM orders WHERE total > {{ bind .Min }} AND user_id IN ({{ sub "active_users" . }}) LIMIT {{ bind .Limit }}`)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			_, args, err := registry.Run("orders", map[string]any{"Min": i, "Org": -i, "Limit": i * 10})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if want := []any{i, -i, i * 10}; !reflect.DeepEqual(args, want) {
+				t.Errorf("goroutine %d: got args %v, want %v", i, args, want)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestRunRecoversFromPanickingFunc(t *testing.T) {
+	engine := NewEngine(WithFuncs(template.FuncMap{
+		"check": func(n int) int {
+			if n < 0 {
+				panic("negative")
+			}
+			return n
+		},
+	}))
+
+	tmpl, err := engine.Compile("SELECT * FROM t WHERE a = {{ bind .A }} AND b = {{ bind (check .B) }}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := tmpl.Run(map[string]any{"A": 1, "B": -1}); err == nil || !strings.Contains(err.Error(), "negative") {
+		t.Fatalf("got error %v, want the recovered panic", err)
+	}
+
+	sql, args, err := tmpl.Run(map[string]any{"A": 2, "B": 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "SELECT * FROM t WHERE a = $1 AND b = $2"; sql != want {
+		t.Errorf("got %q, want %q", sql, want)
+	}
+	if want := []any{2, 3}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got args %v, want %v", args, want)
+	}
+}
@@ -0,0 +1,47 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+)
+
+func QueryContext(ctx context.Context, db *sql.DB, query string, tmplArgs any) (*sql.Rows, error) {
+	return defaultEngine.QueryContext(ctx, db, query, tmplArgs)
+}
+
+func ExecContext(ctx context.Context, db *sql.DB, query string, tmplArgs any) (sql.Result, error) {
+	return defaultEngine.ExecContext(ctx, db, query, tmplArgs)
+}
+
+// QueryRowContext renders query and runs it through db.QueryRowContext.
+// Rendering errors are returned directly since *sql.Row cannot carry them.
+func QueryRowContext(ctx context.Context, db *sql.DB, query string, tmplArgs any) (*sql.Row, error) {
+	return defaultEngine.QueryRowContext(ctx, db, query, tmplArgs)
+}
+
+func (engine *Engine) QueryContext(ctx context.Context, db *sql.DB, query string, tmplArgs any) (*sql.Rows, error) {
+	sqlQuery, args, err := engine.ExecuteContext(ctx, query, tmplArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.QueryContext(ctx, sqlQuery, args...)
+}
+
+func (engine *Engine) ExecContext(ctx context.Context, db *sql.DB, query string, tmplArgs any) (sql.Result, error) {
+	sqlQuery, args, err := engine.ExecuteContext(ctx, query, tmplArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.ExecContext(ctx, sqlQuery, args...)
+}
+
+func (engine *Engine) QueryRowContext(ctx context.Context, db *sql.DB, query string, tmplArgs any) (*sql.Row, error) {
+	sqlQuery, args, err := engine.ExecuteContext(ctx, query, tmplArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.QueryRowContext(ctx, sqlQuery, args...), nil
+}
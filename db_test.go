@@ -0,0 +1,119 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// recorder is a database/sql driver that records the statements it is given
+// and returns no rows.
+type recorder struct {
+	mu    sync.Mutex
+	query string
+	args  []driver.Value
+}
+
+func (r *recorder) Open(string) (driver.Conn, error) { return recorderConn{r}, nil }
+
+type recorderConn struct{ r *recorder }
+
+func (c recorderConn) Prepare(query string) (driver.Stmt, error) {
+	return recorderStmt{c.r, query}, nil
+}
+func (c recorderConn) Close() error              { return nil }
+func (c recorderConn) Begin() (driver.Tx, error) { return nil, driver.ErrSkip }
+
+type recorderStmt struct {
+	r     *recorder
+	query string
+}
+
+func (s recorderStmt) Close() error  { return nil }
+func (s recorderStmt) NumInput() int { return -1 }
+
+func (s recorderStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.record(args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s recorderStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.record(args)
+	return recorderRows{}, nil
+}
+
+func (s recorderStmt) record(args []driver.Value) {
+	s.r.mu.Lock()
+	defer s.r.mu.Unlock()
+	s.r.query, s.r.args = s.query, args
+}
+
+type recorderRows struct{}
+
+func (recorderRows) Columns() []string         { return []string{"id"} }
+func (recorderRows) Close() error              { return nil }
+func (recorderRows) Next([]driver.Value) error { return io.EOF }
+
+var fakeDB = sync.OnceValue(func() *recorder {
+	r := &recorder{}
+	sql.Register("engine-recorder", r)
+	return r
+})
+
+func TestDBHelpers(t *testing.T) {
+	r := fakeDB()
+	db, err := sql.Open("engine-recorder", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	const query = "SELECT id FROM users WHERE id = {{ bind .ID }} AND name = {{ bind .Name }}"
+	params := map[string]any{"ID": 7, "Name": "ada"}
+
+	helpers := map[string]func() error{
+		"QueryContext": func() error {
+			rows, err := QueryContext(context.Background(), db, query, params)
+			if err == nil {
+				rows.Close()
+			}
+			return err
+		},
+		"ExecContext": func() error {
+			_, err := ExecContext(context.Background(), db, query, params)
+			return err
+		},
+		"QueryRowContext": func() error {
+			row, err := QueryRowContext(context.Background(), db, query, params)
+			if err != nil {
+				return err
+			}
+			var id int
+			if err := row.Scan(&id); err != sql.ErrNoRows {
+				return err
+			}
+			return nil
+		},
+	}
+
+	for name, helper := range helpers {
+		t.Run(name, func(t *testing.T) {
+			if err := helper(); err != nil {
+				t.Fatal(err)
+			}
+
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			if want := "SELECT id FROM users WHERE id = $1 AND name = $2"; r.query != want {
+				t.Errorf("got %q, want %q", r.query, want)
+			}
+			if want := []driver.Value{int64(7), "ada"}; !reflect.DeepEqual(r.args, want) {
+				t.Errorf("got args %#v, want %#v", r.args, want)
+			}
+		})
+	}
+}
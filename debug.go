@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExecuteDebug renders query with every bound value inlined as a SQL literal.
+// The result is meant for logs and SQL consoles only: it defeats
+// parameterization and must never be sent to a database.
+func ExecuteDebug(query string, args any) (string, error) {
+	return defaultEngine.ExecuteDebug(query, args)
+}
+
+func (engine *Engine) ExecuteDebug(query string, args any) (string, error) {
+	start := time.Now()
+	tmpl, err := engine.Compile(query)
+	if err != nil {
+		engine.observe(ExecInfo{Duration: time.Since(start), Err: err})
+		return "", err
+	}
+
+	return tmpl.Debug(args)
+}
+
+func (t *Template) Debug(args any) (string, error) {
+	out, _, err := t.run(args, func(parser *SqlParser) {
+		parser.inline = true
+	})
+
+	return out, err
+}
+
+// literal renders arg as a SQL literal. Numbers, strings and bools are
+// rendered by their kind before fmt.Stringer is consulted, since a driver
+// binds a named number, such as time.Duration or an enum, by its value rather
+// than its String.
+func literal(arg any, dialect Dialect) string {
+	switch value := arg.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		return quote(string(value))
+	case time.Time:
+		return quote(value.Format("2006-01-02 15:04:05.999999999Z07:00"))
+	}
+
+	value := reflect.ValueOf(arg)
+	switch value.Kind() {
+	case reflect.String:
+		return quote(value.String())
+	case reflect.Bool:
+		return dialect.boolean(value.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(value.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, value.Type().Bits())
+	}
+
+	if stringer, ok := arg.(fmt.Stringer); ok {
+		return quote(stringer.String())
+	}
+
+	if kind := value.Kind(); kind == reflect.Pointer || kind == reflect.Interface {
+		if value.IsNil() {
+			return "NULL"
+		}
+		return literal(value.Elem().Interface(), dialect)
+	}
+
+	return quote(fmt.Sprint(arg))
+}
+
+func quote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,40 @@
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+type state int
+
+func (s state) String() string {
+	return [...]string{"draft", "active"}[s]
+}
+
+func TestExecuteDebugLiterals(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  any
+		want string
+	}{
+		{"quoted string", "O'Brien", `WHERE name = 'O''Brien'`},
+		{"numeric Stringer", state(1), `WHERE name = 1`},
+		{"duration", 5 * time.Second, `WHERE name = 5000000000`},
+		{"float", 2.5, `WHERE name = 2.5`},
+		{"bool", true, `WHERE name = TRUE`},
+		{"nil", nil, `WHERE name = NULL`},
+		{"pointer", new(int), `WHERE name = 0`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, err := ExecuteDebug(`WHERE name = {{ bind .Name }}`, map[string]any{"Name": test.arg})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != test.want {
+				t.Errorf("got %q, want %q", query, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package engine
+
+import "fmt"
+
+type Dialect int
+
+const (
+	Postgres Dialect = iota
+	MySQL
+	SQLServer
+	Oracle
+)
+
+func (dialect Dialect) String() string {
+	switch dialect {
+	case Postgres:
+		return "postgres"
+	case MySQL:
+		return "mysql"
+	case SQLServer:
+		return "sqlserver"
+	case Oracle:
+		return "oracle"
+	}
+
+	return fmt.Sprintf("Dialect(%d)", int(dialect))
+}
+
+func (dialect Dialect) numbered() bool {
+	return dialect != MySQL
+}
+
+func (dialect Dialect) placeholder(n int) string {
+	switch dialect {
+	case MySQL:
+		return "?"
+	case SQLServer:
+		return fmt.Sprintf("@p%d", n)
+	case Oracle:
+		return fmt.Sprintf(":%d", n)
+	}
+
+	return fmt.Sprintf("$%d", n)
+}
+
+// prefix is the placeholder text, before the number for numbered dialects.
+func (dialect Dialect) prefix() string {
+	switch dialect {
+	case MySQL:
+		return "?"
+	case SQLServer:
+		return "@p"
+	case Oracle:
+		return ":"
+	}
+
+	return "$"
+}
+
+func (dialect Dialect) quote(name string) string {
+	switch dialect {
+	case MySQL:
+		return "`" + name + "`"
+	case SQLServer:
+		return "[" + name + "]"
+	}
+
+	return `"` + name + `"`
+}
+
+func (dialect Dialect) boolean(value bool) string {
+	switch {
+	case dialect == Postgres && value:
+		return "TRUE"
+	case dialect == Postgres:
+		return "FALSE"
+	case value:
+		return "1"
+	}
+
+	return "0"
+}
@@ -0,0 +1,35 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDialectPlaceholders(t *testing.T) {
+	const query = "SELECT * FROM users WHERE a = {{ bind .A }} AND b = {{ bind .B }} AND c = {{ bind .C }}"
+
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{Postgres, "SELECT * FROM users WHERE a = $1 AND b = $2 AND c = $3"},
+		{MySQL, "SELECT * FROM users WHERE a = ? AND b = ? AND c = ?"},
+		{SQLServer, "SELECT * FROM users WHERE a = @p1 AND b = @p2 AND c = @p3"},
+		{Oracle, "SELECT * FROM users WHERE a = :1 AND b = :2 AND c = :3"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dialect.String(), func(t *testing.T) {
+			sql, args, err := NewEngine(WithDialect(test.dialect)).Execute(query, map[string]any{"A": 1, "B": "two", "C": 3.5})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sql != test.want {
+				t.Errorf("got %q, want %q", sql, test.want)
+			}
+			if want := []any{1, "two", 3.5}; !reflect.DeepEqual(args, want) {
+				t.Errorf("got args %v, want %v", args, want)
+			}
+		})
+	}
+}
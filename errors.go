@@ -0,0 +1,120 @@
+package engine
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+)
+
+const snippetSize = 40
+
+var (
+	templateError = regexp.MustCompile(`^template: (.*?):(\d+): (.*)$`)
+	executeError  = regexp.MustCompile(`^template: (.*?):(\d+):\d+: executing`)
+	undefinedFunc = regexp.MustCompile(`function "([^"]+)" not defined`)
+	siteAlias     = regexp.MustCompile(`\b(\w+)` + siteSuffix + `\d+\b`)
+)
+
+// ParseError reports a template that failed to parse. Func is set when the
+// failure is a call to a function that was never registered.
+type ParseError struct {
+	Name string
+	Line int
+	Func string
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return "engine: parse: " + e.Err.Error()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// parseError lifts the template name, line and undefined function out of a
+// text/template parse error message.
+func parseError(err error) error {
+	match := templateError.FindStringSubmatch(err.Error())
+	if match == nil {
+		return fmt.Errorf("engine: parse: %w", err)
+	}
+
+	line, _ := strconv.Atoi(match[2])
+	parsed := &ParseError{Name: match[1], Line: line, Err: err}
+
+	if fn := undefinedFunc.FindStringSubmatch(match[3]); fn != nil {
+		parsed.Func = fn[1]
+	}
+
+	return parsed
+}
+
+// ExecError reports a template that failed while rendering. Line is the
+// template line of the failing action. Offset is the number of bytes rendered
+// before the failure and Snippet the end of that output; they are only known
+// when rendering into a *bytes.Buffer, as Run and Execute do, and Offset is
+// -1 otherwise.
+type ExecError struct {
+	Name    string
+	Line    int
+	Offset  int
+	Snippet string
+	Err     error
+}
+
+func (e *ExecError) Error() string {
+	if e.Offset < 0 {
+		return "engine: execute: " + e.Err.Error()
+	}
+
+	return fmt.Sprintf("engine: execute: %v (at byte %d, after %q)", e.Err, e.Offset, e.Snippet)
+}
+
+func (e *ExecError) Unwrap() error {
+	return e.Err
+}
+
+// execError lifts the template name and line out of a text/template execution
+// error message, and names tracked builtins by their own name instead of
+// their alias. partial is the output rendered before the failure, nil when it
+// is unknown.
+func execError(err error, partial []byte) error {
+	if message := err.Error(); siteAlias.MatchString(message) {
+		err = &untrackedError{message: siteAlias.ReplaceAllString(message, "$1"), err: err}
+	}
+
+	failed := &ExecError{Offset: -1, Err: err}
+	if match := executeError.FindStringSubmatch(err.Error()); match != nil {
+		failed.Name = match[1]
+		failed.Line, _ = strconv.Atoi(match[2])
+	}
+
+	if partial != nil {
+		failed.Offset = len(partial)
+
+		start := max(len(partial)-snippetSize, 0)
+		for start < len(partial) && !utf8.RuneStart(partial[start]) {
+			start++
+		}
+		failed.Snippet = string(partial[start:])
+	}
+
+	return failed
+}
+
+// untrackedError is err with the tracking aliases in its message replaced by
+// the names of their builtins.
+type untrackedError struct {
+	message string
+	err     error
+}
+
+func (e *untrackedError) Error() string {
+	return e.message
+}
+
+func (e *untrackedError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,37 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseErrorUndefinedFunc(t *testing.T) {
+	_, _, err := ExecuteE("SELECT *\nFROM users\nWHERE id = {{ nope .X }}", nil)
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("got %T (%v), want a *ParseError", err, err)
+	}
+	if parseErr.Func != "nope" || parseErr.Line != 3 {
+		t.Errorf("got Func %q and Line %d, want nope on line 3", parseErr.Func, parseErr.Line)
+	}
+}
+
+func TestExecErrorLocation(t *testing.T) {
+	query := "SELECT id\nFROM users\nWHERE a = {{ bind .A }}\nAND b = {{ bind .B }}\nAND c = {{ ident .Table }}"
+
+	_, _, err := ExecuteE(query, map[string]any{"A": 1, "B": 2, "Table": "bad name"})
+
+	var execErr *ExecError
+	if !errors.As(err, &execErr) {
+		t.Fatalf("got %T (%v), want an *ExecError", err, err)
+	}
+
+	rendered := "SELECT id\nFROM users\nWHERE a = $1\nAND b = $2\nAND c = "
+	if execErr.Line != 5 || execErr.Offset != len(rendered) {
+		t.Errorf("got line %d at byte %d, want line 5 at byte %d", execErr.Line, execErr.Offset, len(rendered))
+	}
+	if want := rendered[len(rendered)-snippetSize:]; execErr.Snippet != want {
+		t.Errorf("got snippet %q, want %q", execErr.Snippet, want)
+	}
+}
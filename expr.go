@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"errors"
+	"strings"
+)
+
+var errNoOperands = errors.New("no operands")
+
+// Expr is SQL text rendered by col and the expression helpers. The helpers
+// splice an Expr operand in as is and bind every other operand, so column
+// references and nested expressions must be wrapped in col.
+type Expr string
+
+// Col marks name as a column reference for the expression helpers, quoted
+// like ident.
+func (parser *SqlParser) Col(name string) (Expr, error) {
+	column, err := parser.Ident(name)
+	if err != nil {
+		return "", err
+	}
+
+	return Expr(column), nil
+}
+
+// Coalesce renders COALESCE over its operands.
+func (parser *SqlParser) Coalesce(operands ...any) (Expr, error) {
+	return parser.call("COALESCE", operands)
+}
+
+// Greatest renders GREATEST over its operands. SQL Server supports it from
+// version 2022.
+func (parser *SqlParser) Greatest(operands ...any) (Expr, error) {
+	return parser.call("GREATEST", operands)
+}
+
+// Least renders LEAST over its operands. SQL Server supports it from version
+// 2022.
+func (parser *SqlParser) Least(operands ...any) (Expr, error) {
+	return parser.call("LEAST", operands)
+}
+
+// Concat joins its operands with || on Postgres and Oracle and with CONCAT()
+// on MySQL and SQL Server. The dialects disagree on NULL operands: CONCAT()
+// and Oracle skip them, Postgres || yields NULL. A lone operand is rendered
+// bare, since SQL Server requires at least two CONCAT() arguments.
+func (parser *SqlParser) Concat(operands ...any) (Expr, error) {
+	if len(operands) == 0 {
+		return "", errNoOperands
+	}
+
+	rendered := parser.operands(operands)
+	switch {
+	case len(rendered) == 1:
+		return Expr(rendered[0]), nil
+	case parser.dialect == MySQL, parser.dialect == SQLServer:
+		return Expr("CONCAT(" + strings.Join(rendered, ", ") + ")"), nil
+	}
+
+	return Expr(strings.Join(rendered, " || ")), nil
+}
+
+func (parser *SqlParser) call(function string, operands []any) (Expr, error) {
+	if len(operands) == 0 {
+		return "", errNoOperands
+	}
+
+	return Expr(function + "(" + strings.Join(parser.operands(operands), ", ") + ")"), nil
+}
+
+func (parser *SqlParser) operands(operands []any) []string {
+	rendered := make([]string, len(operands))
+	for i, operand := range operands {
+		if expr, ok := operand.(Expr); ok {
+			rendered[i] = string(expr)
+		} else {
+			rendered[i] = parser.Parse(operand)
+		}
+	}
+
+	return rendered
+}
@@ -0,0 +1,40 @@
+package engine
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestConcat(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		query   string
+		want    string
+	}{
+		{"postgres", Postgres, `{{ concat (col "first_name") " " (col "last_name") }}`, `"first_name" || $1 || "last_name"`},
+		{"mysql", MySQL, `{{ concat (col "first_name") " " (col "last_name") }}`, "CONCAT(`first_name`, ?, `last_name`)"},
+		{"sqlserver", SQLServer, `{{ concat (col "first_name") " " (col "last_name") }}`, "CONCAT([first_name], @p1, [last_name])"},
+		{"lone operand", SQLServer, `{{ concat " " }}`, "@p1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sql, args, err := NewEngine(WithDialect(test.dialect)).Execute(test.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sql != test.want {
+				t.Errorf("got %q, want %q", sql, test.want)
+			}
+			if want := []any{" "}; !reflect.DeepEqual(args, want) {
+				t.Errorf("got args %q, want %q", args, want)
+			}
+		})
+	}
+
+	if _, _, err := ExecuteE(`{{ concat }}`, nil); !errors.Is(err, errNoOperands) {
+		t.Errorf("got %v, want %v", err, errNoOperands)
+	}
+}
@@ -0,0 +1,47 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+)
+
+// TemplateSet holds queries parsed from files, each named after its file.
+// Queries can include each other with {{ template "name" . }}, and args bound
+// in an included query land in the same args slice.
+type TemplateSet struct {
+	engine *Engine
+}
+
+func ParseFS(fsys fs.FS, patterns ...string) (*TemplateSet, error) {
+	return defaultEngine.ParseFS(fsys, patterns...)
+}
+
+func (engine *Engine) ParseFS(fsys fs.FS, patterns ...string) (*TemplateSet, error) {
+	compiled, err := engine.fork()
+	if err != nil {
+		return nil, fmt.Errorf("engine: parse: %w", err)
+	}
+
+	if _, err := compiled.tempalte.ParseFS(fsys, patterns...); err != nil {
+		return nil, parseError(err)
+	}
+
+	if err := compiled.prepare(); err != nil {
+		return nil, err
+	}
+
+	return &TemplateSet{engine: compiled}, nil
+}
+
+func (set *TemplateSet) Run(name string, args any) (string, []any, error) {
+	out := getBuffer()
+	defer putBuffer(out)
+
+	sqlArgs, err := set.engine.render(context.Background(), out, name, args, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return out.String(), sqlArgs, nil
+}
@@ -0,0 +1,31 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestParseFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"queries/tenant.sql.tmpl": {Data: []byte(`tenant_id = {{ bind .TenantID }}`)},
+		"queries/users.sql.tmpl":  {Data: []byte(`SELECT id FROM users WHERE {{ template "tenant.sql.tmpl" . }} AND active = {{ bind .Active }}`)},
+	}
+
+	set, err := ParseFS(fsys, "queries/*.sql.tmpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query, args, err := set.Run("users.sql.tmpl", map[string]any{"TenantID": 3, "Active": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `SELECT id FROM users WHERE tenant_id = $1 AND active = $2`; query != want {
+		t.Errorf("got %q, want %q", query, want)
+	}
+	if want := []any{3, true}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got args %v, want %v", args, want)
+	}
+}
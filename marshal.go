@@ -3,6 +3,7 @@ package engine
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 )
 
 func marshal(value interface{}) string {
@@ -14,3 +15,18 @@ func marshal(value interface{}) string {
 
 	return buf.String()
 }
+
+// JSONB binds value marshalled to JSON as a single string parameter cast to
+// jsonb. Only Postgres has jsonb.
+func (parser *SqlParser) JSONB(value any) (string, error) {
+	if parser.dialect != Postgres {
+		return "", fmt.Errorf("not supported by %s", parser.dialect)
+	}
+
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("marshal: %w", err)
+	}
+
+	return parser.Parse(string(payload)) + "::jsonb", nil
+}
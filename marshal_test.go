@@ -0,0 +1,25 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJSONB(t *testing.T) {
+	const query = "UPDATE events SET payload = {{ jsonb .Payload }} WHERE id = {{ bind .ID }}"
+
+	sql, args, err := ExecuteE(query, map[string]any{"Payload": map[string]any{"kind": "signup", "n": 1}, "ID": 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "UPDATE events SET payload = $1::jsonb WHERE id = $2"; sql != want {
+		t.Errorf("got %q, want %q", sql, want)
+	}
+	if want := []any{`{"kind":"signup","n":1}`, 4}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got args %v, want %v", args, want)
+	}
+
+	if _, _, err := ExecuteE(query, map[string]any{"Payload": make(chan int), "ID": 4}); err == nil {
+		t.Error("an unmarshalable payload did not fail")
+	}
+}
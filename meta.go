@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Meta describes the args bound by one run. It is built fresh for each run,
+// so callers may keep or modify it freely.
+type Meta struct {
+	// Placeholders is the number of distinct placeholders, which is also
+	// the number of bound args.
+	Placeholders int
+	// Kinds holds the kind of each bound arg; nil args are reflect.Invalid.
+	Kinds []reflect.Kind
+	// Sites holds every bind of the run in order, with the template action
+	// that made it. It is only filled in with WithTrackBindSites.
+	Sites []BindSite
+}
+
+// RunMeta is Run plus a Meta of the bound args. Kinds are those of the values
+// the driver will bind, so driver.Valuer args are resolved first and an error
+// from Value fails the run.
+func (t *Template) RunMeta(args any) (string, []any, Meta, error) {
+	var setup func(*SqlParser)
+	var sites []BindSite
+	if t.engine.track {
+		setup = func(parser *SqlParser) { parser.sites = &sites }
+	}
+
+	sql, sqlArgs, err := t.run(args, setup)
+	if err != nil {
+		return "", nil, Meta{}, err
+	}
+
+	meta, err := describe(sqlArgs)
+	if err != nil {
+		return "", nil, Meta{}, fmt.Errorf("engine: execute: %w", err)
+	}
+
+	if t.engine.track {
+		placeSites(sites, sql, t.engine.parser.dialect)
+		meta.Sites = sites
+	}
+
+	return sql, sqlArgs, meta, nil
+}
+
+func describe(args []any) (Meta, error) {
+	kinds := make([]reflect.Kind, len(args))
+	for i, arg := range args {
+		value, err := valueOf(arg)
+		if err != nil {
+			return Meta{}, err
+		}
+		kinds[i] = reflect.ValueOf(value).Kind()
+	}
+
+	return Meta{Placeholders: len(args), Kinds: kinds}, nil
+}
@@ -0,0 +1,42 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRunMeta(t *testing.T) {
+	tmpl, err := Compile(`SELECT * FROM users WHERE {{ if .Name }}name = {{ bind .Name }}{{ else }}name IS NULL AND team = {{ bind .Team }}{{ end }} AND age > {{ bind .Age }}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runs := []map[string]any{
+		{"Name": "ada", "Age": 36},
+		{"Name": "", "Team": nil, "Age": 40},
+	}
+	want := [][]reflect.Kind{
+		{reflect.String, reflect.Int},
+		{reflect.Invalid, reflect.Int},
+	}
+
+	for i, args := range runs {
+		_, _, meta, err := tmpl.RunMeta(args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if meta.Placeholders != 2 || !reflect.DeepEqual(meta.Kinds, want[i]) {
+			t.Errorf("run %d: got %+v, want 2 placeholders of kinds %v", i, meta, want[i])
+		}
+
+		meta.Kinds[0] = reflect.Map
+	}
+
+	_, _, meta, err := tmpl.RunMeta(runs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(meta.Kinds, want[0]) {
+		t.Errorf("kinds changed by an earlier run's caller: got %v, want %v", meta.Kinds, want[0])
+	}
+}
@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"text/template/parse"
+	"time"
+)
+
+// ExecuteNamed renders query with sqlx style :name placeholders, for
+// sqlx.NamedExec and friends. `{{ bind "userID" .UserID }}` renders :userID
+// and stores the value under "userID" in the returned map.
+func ExecuteNamed(query string, args any) (string, map[string]any, error) {
+	return defaultEngine.ExecuteNamed(query, args)
+}
+
+func (engine *Engine) ExecuteNamed(query string, args any) (string, map[string]any, error) {
+	start := time.Now()
+	tmpl, err := engine.Compile(query)
+	if err != nil {
+		engine.observe(ExecInfo{Duration: time.Since(start), Err: err})
+		return "", nil, err
+	}
+
+	return tmpl.RunNamed(args)
+}
+
+func (t *Template) RunNamed(args any) (string, map[string]any, error) {
+	named := make(map[string]any)
+	sql, _, err := t.run(args, func(parser *SqlParser) {
+		parser.named = named
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return sql, named, nil
+}
+
+// bind is the bind template function. It takes a value, or a name and a value
+// when rendering for ExecuteNamed.
+func (parser *SqlParser) bind(args ...any) (string, error) {
+	switch {
+	case len(args) == 1 && parser.named == nil:
+		return parser.Parse(args[0]), nil
+	case len(args) == 1:
+		return "", fmt.Errorf("named placeholders need a name, as in bind \"name\" value")
+	case len(args) == 2 && parser.named == nil:
+		return "", fmt.Errorf("a named bind needs ExecuteNamed")
+	case len(args) == 2:
+		name, ok := args[0].(string)
+		if !ok {
+			return "", fmt.Errorf("expected a string name, got %T", args[0])
+		}
+		return parser.bindName(name, args[1])
+	}
+
+	return "", fmt.Errorf("expected a value, or a name and a value, got %d arguments", len(args))
+}
+
+// bindName stores value under name and renders :name. Binding a name again is
+// allowed only with an equal value, since the placeholders cannot tell two
+// values of one name apart.
+func (parser *SqlParser) bindName(name string, value any) (string, error) {
+	if !identifier.MatchString(name) {
+		return "", fmt.Errorf("invalid parameter name %q", name)
+	}
+
+	value = parser.bindable(value)
+	if bound, ok := parser.named[name]; ok && !reflect.DeepEqual(bound, value) {
+		return "", fmt.Errorf("parameter %q bound to both %v and %v", name, bound, value)
+	}
+
+	parser.named[name] = value
+	return ":" + name, nil
+}
+
+// bindNames turns every bind of a lone string constant, as in
+// `{{ bind "userID" }}`, into a call of arg, which binds the value stored
+// under that name. Only constants can be told apart from values before the
+// run; a name computed at run time needs arg.
+func (engine *Engine) bindNames() {
+	for _, tmpl := range engine.tempalte.Templates() {
+		if tmpl.Tree == nil {
+			continue
+		}
+
+		walk(tmpl.Tree.Root, func(node parse.Node) {
+			commands(node, func(cmd *parse.CommandNode) {
+				if len(cmd.Args) != 2 {
+					return
+				}
+
+				fn, ok := cmd.Args[0].(*parse.IdentifierNode)
+				if _, constant := cmd.Args[1].(*parse.StringNode); ok && constant && fn.Ident == "bind" {
+					fn.Ident = "arg"
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExecuteNamed(t *testing.T) {
+	var observed []ExecInfo
+	engine := NewEngine(WithObserver(func(info ExecInfo) {
+		observed = append(observed, info)
+	}))
+
+	sql, params, err := engine.ExecuteNamed(
+		`SELECT * FROM docs WHERE owner_id = {{ bind "userID" .UserID }} AND title = {{ bind "title" .Title }} OR editor_id = {{ bind "userID" .UserID }}`,
+		map[string]any{"UserID": 7, "Title": "draft"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `SELECT * FROM docs WHERE owner_id = :userID AND title = :title OR editor_id = :userID`; sql != want {
+		t.Errorf("got %q, want %q", sql, want)
+	}
+	if want := map[string]any{"userID": 7, "title": "draft"}; !reflect.DeepEqual(params, want) {
+		t.Errorf("got params %v, want %v", params, want)
+	}
+	if len(observed) != 1 || observed[0].Args != 2 {
+		t.Errorf("observed %+v, want one execution with 2 args", observed)
+	}
+}
+
+func TestExecuteNamedCollision(t *testing.T) {
+	_, _, err := ExecuteNamed(`WHERE a = {{ bind "id" .A }} AND b = {{ bind "id" .B }}`, map[string]any{"A": 1, "B": 2})
+	if err == nil {
+		t.Fatal("binding one name to two values did not fail")
+	}
+}
+
+func TestBindByName(t *testing.T) {
+	query, args, err := ExecuteE(
+		`SELECT * FROM docs WHERE owner_id = {{ bind "userID" }} OR editor_id = {{ bind "userID" }} AND kind = {{ bind (print "memo") }}`,
+		map[string]any{"userID": 7},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `SELECT * FROM docs WHERE owner_id = $1 OR editor_id = $2 AND kind = $3`; query != want {
+		t.Errorf("got %q, want %q", query, want)
+	}
+	if want := []any{7, 7, "memo"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got args %v, want %v", args, want)
+	}
+
+	sql, params, err := ExecuteNamed(`SELECT * FROM docs WHERE owner_id = {{ bind "userID" }}`, map[string]any{"userID": 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `SELECT * FROM docs WHERE owner_id = :userID`; sql != want {
+		t.Errorf("got %q, want %q", sql, want)
+	}
+	if want := map[string]any{"userID": 7}; !reflect.DeepEqual(params, want) {
+		t.Errorf("got params %v, want %v", params, want)
+	}
+
+	if _, _, err := ExecuteE(`WHERE id = {{ bind "missing" }}`, map[string]any{"userID": 7}); err == nil {
+		t.Error("binding a missing name did not fail")
+	}
+}
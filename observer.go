@@ -0,0 +1,34 @@
+package engine
+
+import "time"
+
+// ExecInfo describes one execution for observers. Name is the template name
+// when the query was run by name, SQL is empty when the execution failed.
+type ExecInfo struct {
+	Name     string
+	SQL      string
+	Args     int
+	Duration time.Duration
+	Err      error
+}
+
+// WithObserver calls observer once per execution, whether it succeeded or
+// not. A panic in observer is recovered and ignored.
+func WithObserver(observer func(ExecInfo)) Option {
+	return func(engine *Engine) error {
+		engine.observer = observer
+		return nil
+	}
+}
+
+func (engine *Engine) observe(info ExecInfo) {
+	if engine.observer == nil {
+		return
+	}
+
+	defer func() {
+		_ = recover()
+	}()
+
+	engine.observer(info)
+}
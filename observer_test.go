@@ -0,0 +1,109 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"text/template"
+)
+
+func TestObserverOncePerExecution(t *testing.T) {
+	var infos []ExecInfo
+	engine := NewEngine(WithObserver(func(info ExecInfo) {
+		infos = append(infos, info)
+	}))
+
+	entries := map[string]func(query string) error{
+		"Execute": func(query string) error {
+			_, _, err := engine.Execute(query, map[string]any{"ID": 1})
+			return err
+		},
+		"ExecuteContext": func(query string) error {
+			_, _, err := engine.ExecuteContext(context.Background(), query, map[string]any{"ID": 1})
+			return err
+		},
+		"ExecuteTo": func(query string) error {
+			_, err := engine.ExecuteTo(io.Discard, query, map[string]any{"ID": 1})
+			return err
+		},
+		"ExecuteDebug": func(query string) error {
+			_, err := engine.ExecuteDebug(query, map[string]any{"ID": 1})
+			return err
+		},
+	}
+
+	for name, execute := range entries {
+		t.Run(name, func(t *testing.T) {
+			infos = nil
+			if err := execute("SELECT * FROM users WHERE id = {{ bind .ID }}"); err != nil {
+				t.Fatal(err)
+			}
+			if len(infos) != 1 || infos[0].Err != nil || infos[0].SQL == "" || infos[0].Duration <= 0 {
+				t.Errorf("valid query observed as %+v, want one successful execution", infos)
+			}
+
+			infos = nil
+			if err := execute("SELECT {{ bind .ID"); err == nil {
+				t.Fatal("broken query executed without error")
+			}
+			if len(infos) != 1 || infos[0].Err == nil {
+				t.Errorf("broken query observed as %+v, want one failed execution", infos)
+			}
+		})
+	}
+}
+
+func TestObserverSeesContextErrors(t *testing.T) {
+	var infos []ExecInfo
+	var cancel context.CancelFunc
+	engine := NewEngine(
+		WithObserver(func(info ExecInfo) {
+			infos = append(infos, info)
+		}),
+		WithFuncs(template.FuncMap{"cancel": func() int {
+			cancel()
+			return 1
+		}}),
+	)
+
+	done, stop := context.WithCancel(context.Background())
+	stop()
+
+	entries := map[string]func(ctx context.Context, query string) error{
+		"ExecuteContext": func(ctx context.Context, query string) error {
+			_, _, err := engine.ExecuteContext(ctx, query, map[string]any{"ID": 1})
+			return err
+		},
+		"RunContext": func(ctx context.Context, query string) error {
+			tmpl, err := engine.Compile(query)
+			if err != nil {
+				return err
+			}
+			_, _, err = tmpl.RunContext(ctx, map[string]any{"ID": 1})
+			return err
+		},
+	}
+
+	for name, execute := range entries {
+		t.Run(name, func(t *testing.T) {
+			infos = nil
+			if err := execute(done, "SELECT * FROM users WHERE id = {{ bind .ID }}"); !errors.Is(err, context.Canceled) {
+				t.Errorf("done context: got %v, want context.Canceled", err)
+			}
+			if len(infos) != 1 || !errors.Is(infos[0].Err, context.Canceled) {
+				t.Errorf("done context observed as %+v, want one canceled execution", infos)
+			}
+
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+			infos = nil
+			if err := execute(ctx, "SELECT * FROM users WHERE id = {{ bind (cancel) }}"); !errors.Is(err, context.Canceled) {
+				t.Errorf("context canceled while rendering: got %v, want context.Canceled", err)
+			}
+			if len(infos) != 1 || !errors.Is(infos[0].Err, context.Canceled) || infos[0].SQL != "" {
+				t.Errorf("context canceled while rendering observed as %+v, want one canceled execution", infos)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"fmt"
+	"text/template"
+	"time"
+)
+
+type Option func(*Engine) error
+
+func WithDialect(dialect Dialect) Option {
+	return func(engine *Engine) error {
+		engine.parser.dialect = dialect
+		return nil
+	}
+}
+
+// WithArgDedup reuses the placeholder of an already bound, equal value instead
+// of binding it again. Only numbered dialects support it.
+func WithArgDedup(enabled bool) Option {
+	return func(engine *Engine) error {
+		engine.parser.dedup = enabled
+		return nil
+	}
+}
+
+// WithStartIndex numbers the first placeholder n instead of 1, so the output
+// can be spliced into a statement that already uses $1 to $n-1. The args
+// returned still hold only the values the engine bound. Only numbered
+// dialects support it.
+func WithStartIndex(n int) Option {
+	return func(engine *Engine) error {
+		if n <= 0 {
+			return fmt.Errorf("engine: start index must be positive, got %d", n)
+		}
+
+		engine.parser.skip = n - 1
+		return nil
+	}
+}
+
+// WithTrimBoolTail strips a dangling AND/OR, and a WHERE left empty, before
+// the next clause or the end of the rendered query so conditional filters
+// compose cleanly.
+func WithTrimBoolTail(enabled bool) Option {
+	return func(engine *Engine) error {
+		if enabled {
+			engine.rewrites = append(engine.rewrites, trimBoolTail)
+		}
+		return nil
+	}
+}
+
+// WithTrimWhitespace collapses whitespace runs, newlines included, into single
+// spaces and trims the ends of the rendered query. Quoted literals are kept
+// verbatim.
+func WithTrimWhitespace(enabled bool) Option {
+	return func(engine *Engine) error {
+		if enabled {
+			engine.rewrites = append(engine.rewrites, collapseWhitespace)
+		}
+		return nil
+	}
+}
+
+// WithStripComments removes -- and /* */ comments from the rendered query,
+// leaving comment markers inside quoted literals alone. It always runs before
+// the other rewrites, whatever the option order.
+func WithStripComments(enabled bool) Option {
+	return func(engine *Engine) error {
+		if enabled {
+			engine.rewrites = append([]func(string) string{stripComments}, engine.rewrites...)
+		}
+		return nil
+	}
+}
+
+// WithTimeEncoder converts every bound time.Time, and the time.Time of every
+// non-nil *time.Time, with encode, e.g. to a formatted string for drivers that
+// mishandle zones. Without it time.Time values are bound unchanged.
+func WithTimeEncoder(encode func(time.Time) any) Option {
+	return func(engine *Engine) error {
+		engine.parser.encode = encode
+		return nil
+	}
+}
+
+func WithFuncs(funcs template.FuncMap) Option {
+	return func(engine *Engine) error {
+		reserved := engine.builtins()
+		for name := range funcs {
+			if _, ok := reserved[name]; ok {
+				return fmt.Errorf("engine: function %q collides with a built-in function", name)
+			}
+		}
+
+		engine.tempalte.Funcs(funcs)
+		return nil
+	}
+}
+
+// WithDelims replaces the {{ and }} action delimiters, which frees them for
+// literal use, e.g. in JSON fragments.
+func WithDelims(left, right string) Option {
+	return func(engine *Engine) error {
+		if left == "" || right == "" {
+			return fmt.Errorf("engine: delimiters must not be empty, got %q and %q", left, right)
+		}
+
+		engine.tempalte.Delims(left, right)
+		return nil
+	}
+}
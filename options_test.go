@@ -0,0 +1,151 @@
+package engine
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+)
+
+func TestNewEngineOptions(t *testing.T) {
+	engine, err := NewEngineE(
+		WithDialect(SQLServer),
+		WithFuncs(template.FuncMap{"lower": strings.ToLower}),
+		WithDelims("[[", "]]"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query, args, err := engine.Execute(`SELECT * FROM users WHERE email = [[ bind (lower .Email) ]] AND note = '{{ keep }}'`, map[string]any{"Email": "Ada@Example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `SELECT * FROM users WHERE email = @p1 AND note = '{{ keep }}'`; query != want {
+		t.Errorf("got %q, want %q", query, want)
+	}
+	if want := []any{"ada@example.com"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got args %v, want %v", args, want)
+	}
+}
+
+type status int
+
+func TestWithFuncs(t *testing.T) {
+	engine, err := NewEngineE(WithFuncs(template.FuncMap{
+		"statusCode": func(s status) int { return int(s) },
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query, args, err := engine.Execute(`SELECT * FROM jobs WHERE status = {{ bind (statusCode .Status) }}`, map[string]any{"Status": status(2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `SELECT * FROM jobs WHERE status = $1`; query != want {
+		t.Errorf("got %q, want %q", query, want)
+	}
+	if want := []any{2}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got args %#v, want %#v", args, want)
+	}
+
+	_, err = NewEngineE(WithFuncs(template.FuncMap{"bind": func(any) string { return "" }}))
+	if err == nil || !strings.Contains(err.Error(), `"bind"`) {
+		t.Errorf("got error %v, want a collision with bind", err)
+	}
+}
+
+func TestWithArgDedup(t *testing.T) {
+	engine, err := NewEngineE(WithArgDedup(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query, args, err := engine.Execute(`SELECT * FROM docs WHERE owner = {{ bind .Name }} OR editor = {{ bind .Name }}`, map[string]any{"Name": "ada"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `SELECT * FROM docs WHERE owner = $1 OR editor = $1`; query != want {
+		t.Errorf("got %q, want %q", query, want)
+	}
+	if want := []any{"ada"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got args %v, want %v", args, want)
+	}
+
+	if _, err := NewEngineE(WithDialect(MySQL), WithArgDedup(true)); err == nil {
+		t.Error("dedup on MySQL did not fail")
+	}
+}
+
+func TestWithDelims(t *testing.T) {
+	engine := NewEngine(WithDelims("[[", "]]"))
+
+	query, args, err := engine.Execute(`SELECT '{"a": {{ "b" }}}'::jsonb @> [[ bind .Filter ]]`, map[string]any{"Filter": `{"b": 1}`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `SELECT '{"a": {{ "b" }}}'::jsonb @> $1`; query != want {
+		t.Errorf("got %q, want %q", query, want)
+	}
+	if want := []any{`{"b": 1}`}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got args %v, want %v", args, want)
+	}
+
+	for _, delims := range [][2]string{{"", "]]"}, {"[[", ""}} {
+		if _, err := NewEngineE(WithDelims(delims[0], delims[1])); err == nil {
+			t.Errorf("delimiters %q were accepted", delims)
+		}
+	}
+}
+
+func TestWithTimeEncoder(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 30, 0, 0, time.FixedZone("CET", 3600))
+	const query = "SELECT * FROM events WHERE at = {{ bind .At }} AND since = {{ bind .Since }} AND until = {{ bind .Until }}"
+	args := map[string]any{"At": at, "Since": &at, "Until": (*time.Time)(nil)}
+
+	_, got, err := ExecuteE(query, args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []any{at, at, nil}; !reflect.DeepEqual(got, want) {
+		t.Errorf("default: got args %v, want %v", got, want)
+	}
+
+	engine := NewEngine(WithTimeEncoder(func(t time.Time) any {
+		return t.UTC().Format(time.RFC3339)
+	}))
+	_, got, err = engine.Execute(query, args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []any{"2024-03-01T11:30:00Z", "2024-03-01T11:30:00Z", nil}; !reflect.DeepEqual(got, want) {
+		t.Errorf("encoded: got args %v, want %v", got, want)
+	}
+}
+
+func TestWithStartIndex(t *testing.T) {
+	engine, err := NewEngineE(WithStartIndex(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query, args, err := engine.Execute("UPDATE t SET a = {{ bind .A }}, b = {{ bind .B }}", map[string]any{"A": 1, "B": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "UPDATE t SET a = $4, b = $5"; query != want {
+		t.Errorf("got %q, want %q", query, want)
+	}
+	if want := []any{1, 2}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got args %v, want %v", args, want)
+	}
+
+	for _, n := range []int{0, -1} {
+		if _, err := NewEngineE(WithStartIndex(n)); err == nil {
+			t.Errorf("start index %d was accepted", n)
+		}
+	}
+}
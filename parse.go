@@ -1,21 +1,77 @@
 package engine
 
 import (
-	"bytes"
+	"context"
+	"io"
+	"time"
 )
 
 func Execute(query string, args any) (string, []any) {
-	engine := NewEngine()
-
-	tmpl, err := engine.tempalte.Parse(query)
+	out, sqlArgs, err := ExecuteE(query, args)
 	if err != nil {
 		panic(err)
 	}
 
-	out := new(bytes.Buffer)
-	if err := tmpl.Execute(out, args); err != nil {
-		panic(err)
+	return out, sqlArgs
+}
+
+func ExecuteE(query string, args any) (string, []any, error) {
+	return defaultEngine.Execute(query, args)
+}
+
+func (engine *Engine) Execute(query string, args any) (string, []any, error) {
+	start := time.Now()
+	tmpl, err := engine.Compile(query)
+	if err != nil {
+		engine.observe(ExecInfo{Duration: time.Since(start), Err: err})
+		return "", nil, err
+	}
+
+	return tmpl.Run(args)
+}
+
+// ExecuteContext renders query unless ctx is already done, and discards the
+// result if ctx is done by the time rendering finishes.
+func ExecuteContext(ctx context.Context, query string, args any) (string, []any, error) {
+	return defaultEngine.ExecuteContext(ctx, query, args)
+}
+
+func (engine *Engine) ExecuteContext(ctx context.Context, query string, args any) (string, []any, error) {
+	start := time.Now()
+	tmpl, err := engine.Compile(query)
+	if err != nil {
+		engine.observe(ExecInfo{Duration: time.Since(start), Err: err})
+		return "", nil, err
+	}
+
+	return tmpl.RunContext(ctx, args)
+}
+
+// ExecutePrepared renders query for db.Prepare: args still steer the
+// template's branches, but the bound values are discarded and only the
+// placeholder SQL is returned.
+func ExecutePrepared(query string, args any) (string, error) {
+	return defaultEngine.ExecutePrepared(query, args)
+}
+
+func (engine *Engine) ExecutePrepared(query string, args any) (string, error) {
+	sql, _, err := engine.Execute(query, args)
+	return sql, err
+}
+
+// ExecuteTo renders query straight into w and returns only the bound args.
+// When execution fails, w may already hold part of the query.
+func ExecuteTo(w io.Writer, query string, args any) ([]any, error) {
+	return defaultEngine.ExecuteTo(w, query, args)
+}
+
+func (engine *Engine) ExecuteTo(w io.Writer, query string, args any) ([]any, error) {
+	start := time.Now()
+	tmpl, err := engine.Compile(query)
+	if err != nil {
+		engine.observe(ExecInfo{Duration: time.Since(start), Err: err})
+		return nil, err
 	}
 
-	return out.String(), engine.parser.args
+	return tmpl.RunTo(w, args)
 }
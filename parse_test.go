@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExecuteEUnclosedAction(t *testing.T) {
+	const query = "SELECT * FROM users WHERE id = {{ bind .ID"
+
+	_, _, err := ExecuteE(query, map[string]any{"ID": 1})
+	if err == nil {
+		t.Fatal("unclosed action executed without error")
+	}
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Errorf("got %T (%v), want a *ParseError", err, err)
+	}
+
+	defer func() {
+		if recovered := recover(); recovered == nil {
+			t.Error("Execute did not panic on an unclosed action")
+		}
+	}()
+	Execute(query, map[string]any{"ID": 1})
+}
+
+func TestExecuteToMatchesExecute(t *testing.T) {
+	const query = "SELECT * FROM users WHERE id = {{ bind .ID }} AND name = {{ bind .Name }}"
+	args := map[string]any{"ID": 1, "Name": "ada"}
+
+	want, wantArgs, err := ExecuteE(query, args)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	gotArgs, err := ExecuteTo(&out, query, args)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out.String() != want {
+		t.Errorf("got %q, want %q", out.String(), want)
+	}
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Errorf("got args %v, want %v", gotArgs, wantArgs)
+	}
+}
+
+func TestExecuteContextDone(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	expired, stop := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer stop()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want error
+	}{
+		{"canceled", canceled, context.Canceled},
+		{"expired", expired, context.DeadlineExceeded},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sql, args, err := ExecuteContext(test.ctx, "SELECT * FROM users WHERE id = {{ bind .ID }}", map[string]any{"ID": 1})
+			if !errors.Is(err, test.want) {
+				t.Errorf("got %v, want %v", err, test.want)
+			}
+			if sql != "" || args != nil {
+				t.Errorf("done context rendered %q with args %v", sql, args)
+			}
+		})
+	}
+}
+
+func TestExecutePrepared(t *testing.T) {
+	const query = "SELECT * FROM users WHERE id = {{ bind .ID }}{{ if .Flag }} AND active = {{ bind .Flag }}{{ end }}"
+
+	tests := []struct {
+		flag bool
+		want string
+	}{
+		{true, "SELECT * FROM users WHERE id = $1 AND active = $2"},
+		{false, "SELECT * FROM users WHERE id = $1"},
+	}
+
+	for _, test := range tests {
+		sql, err := ExecutePrepared(query, map[string]any{"ID": 1, "Flag": test.flag})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sql != test.want {
+			t.Errorf("Flag %v: got %q, want %q", test.flag, sql, test.want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package engine
+
+import (
+	"bytes"
+	"sync"
+)
+
+var buffers = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+func getBuffer() *bytes.Buffer {
+	return buffers.Get().(*bytes.Buffer)
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	buf.Reset()
+	buffers.Put(buf)
+}
+
+// acquire returns a fork of the engine ready to execute, reusing one released
+// by an earlier execution when possible.
+func (engine *Engine) acquire() (*Engine, error) {
+	if run, ok := engine.runs.Get().(*Engine); ok {
+		return run, nil
+	}
+
+	return engine.fork()
+}
+
+// release empties the parser of run and returns run to the pool.
+func (engine *Engine) release(run *Engine) {
+	run.parser.recycle(engine.parser)
+	engine.runs.Put(run)
+}
@@ -0,0 +1,92 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+const benchQuery = `SELECT id, name FROM users WHERE org_id = {{ bind .Org }}{{ if .Name }} AND name = {{ bind .Name }}{{ end }} AND id IN ({{ bindSlice .IDs }}) LIMIT 10`
+
+var benchArgs = map[string]any{"Org": 7, "Name": "ada", "IDs": []int{1, 2, 3}}
+
+func TestRunDoesNotLeakArgs(t *testing.T) {
+	tmpl, err := Compile(benchQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, first, err := tmpl.Run(map[string]any{"Org": 1, "Name": "ada", "IDs": []int{1, 2, 3, 4}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, second, err := tmpl.Run(map[string]any{"Org": 2, "IDs": []int{5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []any{2, 5}; !reflect.DeepEqual(second, want) {
+		t.Errorf("second run bound %v, want %v", second, want)
+	}
+	if want := []any{1, "ada", 1, 2, 3, 4}; !reflect.DeepEqual(first, want) {
+		t.Errorf("first run args changed to %v, want %v", first, want)
+	}
+}
+
+func TestExecuteReusesCompiledTemplate(t *testing.T) {
+	engine := NewEngine()
+
+	first, err := engine.Compile(benchQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := engine.Compile(benchQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first != second {
+		t.Error("compiling the same query twice built two templates")
+	}
+}
+
+// BenchmarkExecuteUncached is the baseline for BenchmarkExecute: it forks the
+// engine and parses the query on every call, as Execute did before templates
+// were cached.
+func BenchmarkExecuteUncached(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		tmpl, err := defaultEngine.compile("", benchQuery)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, _, err := tmpl.Run(benchArgs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkExecute(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := ExecuteE(benchQuery, benchArgs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRun(b *testing.B) {
+	tmpl, err := Compile(benchQuery)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := tmpl.Run(benchArgs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Registry is a central store of named, precompiled queries. It is safe for
+// concurrent use.
+type Registry struct {
+	engine    *Engine
+	mu        sync.RWMutex
+	templates map[string]*Template
+}
+
+// NewRegistry compiles queries with engine, or with NewEngine() when engine
+// is nil.
+func NewRegistry(engine *Engine) *Registry {
+	if engine == nil {
+		engine = NewEngine()
+	}
+
+	return &Registry{
+		engine:    engine,
+		templates: make(map[string]*Template),
+	}
+}
+
+// Register compiles query under name. The query can embed any other
+// registered query with {{ sub "name" . }}.
+func (registry *Registry) Register(name, query string) error {
+	tmpl, err := registry.engine.compile(name, query)
+	if err != nil {
+		return err
+	}
+	tmpl.engine.lookup = registry.Lookup
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if _, ok := registry.templates[name]; ok {
+		return fmt.Errorf("engine: query %q is already registered", name)
+	}
+
+	registry.templates[name] = tmpl
+	return nil
+}
+
+func (registry *Registry) MustRegister(name, query string) {
+	if err := registry.Register(name, query); err != nil {
+		panic(err)
+	}
+}
+
+func (registry *Registry) Lookup(name string) (*Template, error) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	tmpl, ok := registry.templates[name]
+	if !ok {
+		return nil, fmt.Errorf("engine: no query registered as %q", name)
+	}
+
+	return tmpl, nil
+}
+
+func (registry *Registry) Run(name string, args any) (string, []any, error) {
+	tmpl, err := registry.Lookup(name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return tmpl.Run(args)
+}
+
+// sub renders a registered query inline. The subquery binds into the parser
+// of the outer query, so its args are spliced in between the outer args bound
+// before and after it.
+func (engine *Engine) sub(name string, data any) (string, error) {
+	if engine.lookup == nil {
+		return "", fmt.Errorf("sub %q: subqueries are only available to queries in a Registry", name)
+	}
+
+	if engine.depth >= maxIncludeDepth {
+		return "", errIncludeDepth
+	}
+
+	tmpl, err := engine.lookup(name)
+	if err != nil {
+		return "", err
+	}
+
+	run, err := tmpl.engine.forkWith(engine.parser)
+	if err != nil {
+		return "", err
+	}
+	run.depth = engine.depth + 1
+
+	out := new(strings.Builder)
+	if err := run.tempalte.ExecuteTemplate(out, tmpl.name, data); err != nil {
+		if errors.Is(err, errIncludeDepth) {
+			return "", errIncludeDepth
+		}
+		return "", err
+	}
+
+	return out.String(), nil
+}
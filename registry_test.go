@@ -0,0 +1,30 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	queries := NewRegistry(nil)
+	queries.MustRegister("user_by_id", "SELECT * FROM users WHERE id = {{ bind .ID }}")
+	queries.MustRegister("user_by_email", "SELECT * FROM users WHERE email = {{ bind .Email }}")
+
+	sql, args, err := queries.Run("user_by_email", map[string]any{"Email": "ada@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "SELECT * FROM users WHERE email = $1"; sql != want {
+		t.Errorf("got %q, want %q", sql, want)
+	}
+	if want := []any{"ada@example.com"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got args %v, want %v", args, want)
+	}
+
+	if _, _, err := queries.Run("user_by_name", nil); err == nil {
+		t.Error("running an unknown query did not fail")
+	}
+	if err := queries.Register("user_by_id", "SELECT 1"); err == nil {
+		t.Error("registering a name twice did not fail")
+	}
+}
@@ -0,0 +1,167 @@
+package engine
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	boolTail   = regexp.MustCompile(`(?i)\s+(?:AND|OR)\s*(` + clauseEnd + `)`)
+	whereTail  = regexp.MustCompile(`(?i)\s+WHERE\s*(` + clauseEnd + `)`)
+	whitespace = regexp.MustCompile(`\s+`)
+)
+
+// clauseEnd matches where a WHERE condition list ends: the end of the query, a
+// semicolon, a closing parenthesis or the keyword of the next clause.
+const clauseEnd = `$|;|\)|\b(?:GROUP|HAVING|WINDOW|ORDER|LIMIT|OFFSET|FETCH|FOR|UNION|INTERSECT|EXCEPT|RETURNING)\b`
+
+// trimBoolTail drops dangling AND/OR operators left by skipped conditions at
+// the end of a condition list, and then a WHERE with no conditions at all.
+// Quoted literals and comments are never matched.
+func trimBoolTail(sql string) string {
+	masked := maskQuoted(sql)
+	for _, tail := range []*regexp.Regexp{boolTail, whereTail} {
+		for {
+			match := tail.FindStringSubmatchIndex(masked)
+			if match == nil {
+				break
+			}
+
+			// Keep a space before the next clause keyword, none before
+			// punctuation or the end of the query.
+			gap := ""
+			if match[3] > match[2] && masked[match[2]] != ';' && masked[match[2]] != ')' {
+				gap = " "
+			}
+
+			sql = sql[:match[0]] + gap + sql[match[2]:]
+			masked = masked[:match[0]] + gap + masked[match[2]:]
+		}
+	}
+
+	return sql
+}
+
+// maskQuoted blanks out quoted literals and comments with NUL bytes, keeping
+// offsets, so patterns run on the result only see plain SQL text.
+func maskQuoted(sql string) string {
+	out := make([]byte, 0, len(sql))
+	scanSQL(sql, func(text string, kind segment) {
+		if kind == plainText {
+			out = append(out, text...)
+		} else {
+			out = append(out, strings.Repeat("\x00", len(text))...)
+		}
+	})
+
+	return string(out)
+}
+
+// segment is the kind of a run of SQL reported by scanSQL.
+type segment int
+
+const (
+	plainText segment = iota
+	quotedText
+	commentText
+)
+
+// collapseWhitespace turns every run of whitespace outside quoted literals
+// and comments into a single space. The newline ending a -- comment is kept,
+// or the comment would swallow the rest of the query.
+func collapseWhitespace(sql string) string {
+	out := new(strings.Builder)
+	lineComment := false
+	scanSQL(sql, func(text string, kind segment) {
+		if kind != plainText {
+			out.WriteString(text)
+			lineComment = strings.HasPrefix(text, "--")
+			return
+		}
+
+		text = whitespace.ReplaceAllString(text, " ")
+		if lineComment {
+			text = "\n" + strings.TrimPrefix(text, " ")
+		}
+		out.WriteString(text)
+		lineComment = false
+	})
+
+	return strings.TrimSpace(out.String())
+}
+
+// stripComments removes -- and /* */ comments outside quoted literals. A block
+// comment becomes a space so the tokens around it stay apart.
+func stripComments(sql string) string {
+	out := new(strings.Builder)
+	scanSQL(sql, func(text string, kind segment) {
+		switch {
+		case kind != commentText:
+			out.WriteString(text)
+		case strings.HasPrefix(text, "/*"):
+			out.WriteString(" ")
+		}
+	})
+
+	return out.String()
+}
+
+// scanSQL splits sql into plain text, quoted runs and comments, in order.
+// Single quoted strings, double quoted identifiers and backtick identifiers
+// are quoted runs; a doubled quote character inside one is an escaped quote.
+// A -- comment ends before the next newline, a /* */ comment after its closing
+// */. An unterminated literal or comment runs to the end of the input.
+func scanSQL(sql string, visit func(text string, kind segment)) {
+	start := 0
+	for i := 0; i < len(sql); i++ {
+		end, kind := i, plainText
+		switch {
+		case sql[i] == '\'' || sql[i] == '"' || sql[i] == '`':
+			end, kind = quotedEnd(sql, i), quotedText
+		case strings.HasPrefix(sql[i:], "--"):
+			end, kind = len(sql), commentText
+			if newline := strings.IndexByte(sql[i:], '\n'); newline >= 0 {
+				end = i + newline
+			}
+		case strings.HasPrefix(sql[i:], "/*"):
+			end, kind = len(sql), commentText
+			if close := strings.Index(sql[i+2:], "*/"); close >= 0 {
+				end = i + 2 + close + 2
+			}
+		default:
+			continue
+		}
+
+		if start < i {
+			visit(sql[start:i], plainText)
+		}
+
+		visit(sql[i:end], kind)
+		start = end
+		i = end - 1
+	}
+
+	if start < len(sql) {
+		visit(sql[start:], plainText)
+	}
+}
+
+// quotedEnd returns the index just past the quoted run starting at sql[start].
+func quotedEnd(sql string, start int) int {
+	quote := sql[start]
+
+	end := start + 1
+	for end < len(sql) {
+		if sql[end] != quote {
+			end++
+			continue
+		}
+		if end+1 < len(sql) && sql[end+1] == quote {
+			end += 2
+			continue
+		}
+		return end + 1
+	}
+
+	return end
+}
@@ -0,0 +1,78 @@
+package engine
+
+import "testing"
+
+func TestTrimBoolTail(t *testing.T) {
+	filter := `SELECT * FROM users WHERE {{ if .Name }}name = {{ bind .Name }} AND {{ end }}{{ if .Age }}age = {{ bind .Age }}{{ end }} ORDER BY id`
+
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"all present", map[string]any{"Name": "ada", "Age": 36}, "SELECT * FROM users WHERE name = $1 AND age = $2 ORDER BY id"},
+		{"some present", map[string]any{"Name": "ada"}, "SELECT * FROM users WHERE name = $1 ORDER BY id"},
+		{"none present", map[string]any{}, "SELECT * FROM users ORDER BY id"},
+	}
+
+	engine := NewEngine(WithTrimBoolTail(true))
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sql, _, err := engine.Execute(filter, test.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sql != test.want {
+				t.Errorf("got %q, want %q", sql, test.want)
+			}
+		})
+	}
+}
+
+func TestTrimBoolTailClauses(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{"WHERE a = 1 AND", "WHERE a = 1"},
+		{"WHERE a = 1 or ;", "WHERE a = 1;"},
+		{"SELECT * FROM t WHERE ", "SELECT * FROM t"},
+		{"SELECT * FROM t WHERE a = 1 AND\n  LIMIT 5", "SELECT * FROM t WHERE a = 1 LIMIT 5"},
+		{"SELECT * FROM (SELECT id FROM u WHERE x = 1 AND ) s WHERE GROUP BY id", "SELECT * FROM (SELECT id FROM u WHERE x = 1) s GROUP BY id"},
+		{"WHERE a = 1 AND order_id = 2 OR limited = 1", "WHERE a = 1 AND order_id = 2 OR limited = 1"},
+		{"WHERE a = ' AND ORDER' AND b = 'x WHERE )'", "WHERE a = ' AND ORDER' AND b = 'x WHERE )'"},
+	}
+
+	for _, test := range tests {
+		if got := trimBoolTail(test.sql); got != test.want {
+			t.Errorf("trimBoolTail(%q) = %q, want %q", test.sql, got, test.want)
+		}
+	}
+}
+
+func TestTrimWhitespace(t *testing.T) {
+	sql, _, err := NewEngine(WithTrimWhitespace(true)).Execute("SELECT *\n  FROM t\n WHERE x = '  two spaces  '\n", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `SELECT * FROM t WHERE x = '  two spaces  '`; sql != want {
+		t.Errorf("got %q, want %q", sql, want)
+	}
+}
+
+func TestStripComments(t *testing.T) {
+	query := "SELECT id -- the key\nFROM t WHERE note = '-- not a comment /* nor this */' /* spans\nlines */ AND id = {{ bind .ID }}"
+
+	sql, args, err := NewEngine(WithStripComments(true)).Execute(query, map[string]any{"ID": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "SELECT id \nFROM t WHERE note = '-- not a comment /* nor this */'   AND id = $1"; sql != want {
+		t.Errorf("got %q, want %q", sql, want)
+	}
+	if len(args) != 1 {
+		t.Errorf("got args %v, want one", args)
+	}
+}
@@ -0,0 +1,193 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Values renders a VALUES list with one tuple per element of rows, binding
+// the named columns of each element in order, see rowValues. An empty slice
+// is an error because an empty VALUES list is invalid SQL.
+func (parser *SqlParser) Values(rows any, columns ...string) (string, error) {
+	list := reflect.ValueOf(rows)
+	if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+		return "", fmt.Errorf("expected slice or array, got %T", rows)
+	}
+
+	if list.Len() == 0 {
+		return "", fmt.Errorf("no rows to insert")
+	}
+
+	if len(columns) == 0 {
+		return "", fmt.Errorf("no columns to insert")
+	}
+
+	tuples := make([]string, list.Len())
+	for i := range tuples {
+		values, err := rowValues(list.Index(i), columns)
+		if err != nil {
+			return "", fmt.Errorf("row %d: %w", i, err)
+		}
+
+		placeholders := make([]string, len(values))
+		for j, value := range values {
+			placeholders[j] = parser.Parse(value)
+		}
+
+		tuples[i] = "(" + strings.Join(placeholders, ", ") + ")"
+	}
+
+	return "VALUES " + strings.Join(tuples, ", "), nil
+}
+
+type column struct {
+	name  string
+	field string
+	value reflect.Value
+}
+
+// Columns lists the column names of a struct, see structColumns.
+func (parser *SqlParser) Columns(arg any) (string, error) {
+	columns, err := structColumns(reflect.ValueOf(arg))
+	if err != nil {
+		return "", err
+	}
+
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.name
+	}
+
+	return strings.Join(names, ", "), nil
+}
+
+// BindStruct binds the column values of a struct in the same order Columns
+// lists their names.
+func (parser *SqlParser) BindStruct(arg any) (string, error) {
+	columns, err := structColumns(reflect.ValueOf(arg))
+	if err != nil {
+		return "", err
+	}
+
+	placeholders := make([]string, len(columns))
+	for i, column := range columns {
+		placeholders[i] = parser.Parse(column.value.Interface())
+	}
+
+	return strings.Join(placeholders, ", "), nil
+}
+
+// structColumns maps the exported fields of a struct to columns. A field is
+// named by its db tag, or by its Go name when untagged; db:"-" skips it.
+// Untagged embedded structs contribute their own columns in place.
+func structColumns(value reflect.Value) ([]column, error) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil, fmt.Errorf("nil struct")
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected struct, got %s", value.Kind())
+	}
+
+	columns := make([]column, 0, value.NumField())
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("db"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+
+			if embedded.Kind() == reflect.Struct {
+				nested, err := structColumns(value.Field(i))
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", field.Name, err)
+				}
+
+				columns = append(columns, nested...)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		columns = append(columns, column{name: name, field: field.Name, value: value.Field(i)})
+	}
+
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns in %s", value.Type())
+	}
+
+	return columns, nil
+}
+
+// rowValues returns the values of row for columns, in order. A slice or array
+// row holds the values in column order, a map row is keyed by column, and a
+// struct row is matched by column name, its db tag or else its Go field name,
+// the same names Columns lists. A name no column has still matches the Go
+// name of a tagged field, as values did before it read tags.
+func rowValues(row reflect.Value, columns []string) ([]any, error) {
+	for row.Kind() == reflect.Pointer || row.Kind() == reflect.Interface {
+		if row.IsNil() {
+			return nil, fmt.Errorf("nil row")
+		}
+		row = row.Elem()
+	}
+
+	values := make([]any, len(columns))
+	switch row.Kind() {
+	case reflect.Slice, reflect.Array:
+		if row.Len() != len(columns) {
+			return nil, fmt.Errorf("expected %d values, got %d", len(columns), row.Len())
+		}
+		for i := range values {
+			values[i] = row.Index(i).Interface()
+		}
+	case reflect.Struct:
+		fields, err := structColumns(row)
+		if err != nil {
+			return nil, err
+		}
+		for i, name := range columns {
+			index := slices.IndexFunc(fields, func(field column) bool { return field.name == name })
+			if index < 0 {
+				index = slices.IndexFunc(fields, func(field column) bool { return field.field == name })
+			}
+			if index < 0 {
+				return nil, fmt.Errorf("no column %q in %s", name, row.Type())
+			}
+			values[i] = fields[index].value.Interface()
+		}
+	case reflect.Map:
+		if row.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("expected a map with string keys, got %s", row.Type())
+		}
+		for i, name := range columns {
+			value := row.MapIndex(reflect.ValueOf(name).Convert(row.Type().Key()))
+			if !value.IsValid() {
+				return nil, fmt.Errorf("no column %q in %s", name, row.Type())
+			}
+			values[i] = value.Interface()
+		}
+	default:
+		return nil, fmt.Errorf("expected struct, map or slice row, got %s", row.Type())
+	}
+
+	return values, nil
+}
@@ -0,0 +1,109 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+type orderKey struct {
+	Tenant  int    `db:"tenant_id"`
+	OrderNo string `db:"order_no"`
+	Note    string
+}
+
+func TestValuesMatchesColumnNames(t *testing.T) {
+	rows := []orderKey{{1, "a", "x"}, {2, "b", "y"}}
+
+	sql, args, err := ExecuteE(`INSERT INTO orders (tenant_id, order_no, Note) {{ values .Rows "tenant_id" "order_no" "Note" }}`, map[string]any{"Rows": rows})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `INSERT INTO orders (tenant_id, order_no, Note) VALUES ($1, $2, $3), ($4, $5, $6)`; sql != want {
+		t.Errorf("got %q, want %q", sql, want)
+	}
+	if want := []any{1, "a", "x", 2, "b", "y"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got args %v, want %v", args, want)
+	}
+
+	sql, args, err = ExecuteE(`{{ values .Rows "Tenant" "order_no" }}`, map[string]any{"Rows": rows})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `VALUES ($1, $2), ($3, $4)`; sql != want {
+		t.Errorf("Go field names: got %q, want %q", sql, want)
+	}
+	if want := []any{1, "a", 2, "b"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("Go field names: got args %v, want %v", args, want)
+	}
+
+	if _, _, err := ExecuteE(`{{ values .Rows "tenant" }}`, map[string]any{"Rows": rows}); err == nil {
+		t.Error("an unknown column matched")
+	}
+}
+
+func TestTuples(t *testing.T) {
+	data := map[string]any{"Keys": []orderKey{{1, "a", ""}, {2, "b", ""}}}
+	query := `SELECT * FROM orders WHERE {{ tuples .Keys "tenant_id" "order_no" }}`
+
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{Postgres, `SELECT * FROM orders WHERE ("tenant_id", "order_no") IN (($1, $2), ($3, $4))`},
+		{SQLServer, `SELECT * FROM orders WHERE (([tenant_id] = @p1 AND [order_no] = @p2) OR ([tenant_id] = @p3 AND [order_no] = @p4))`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dialect.String(), func(t *testing.T) {
+			sql, args, err := NewEngine(WithDialect(test.dialect)).Execute(query, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if sql != test.want {
+				t.Errorf("got %q, want %q", sql, test.want)
+			}
+			if want := []any{1, "a", 2, "b"}; !reflect.DeepEqual(args, want) {
+				t.Errorf("got args %v, want %v", args, want)
+			}
+		})
+	}
+
+	sql, args, err := ExecuteE(query, map[string]any{"Keys": []orderKey{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `SELECT * FROM orders WHERE 1 = 0`; sql != want || len(args) != 0 {
+		t.Errorf("empty keys rendered %q with args %v, want %q", sql, args, want)
+	}
+}
+
+type audit struct {
+	CreatedBy string `db:"created_by"`
+}
+
+type account struct {
+	audit
+	ID       int    `db:"id"`
+	Email    string `db:"email"`
+	Password string `db:"-"`
+	Plan     string
+	internal string
+}
+
+func TestColumnsAndBindStruct(t *testing.T) {
+	row := account{audit: audit{CreatedBy: "ops"}, ID: 7, Email: "ada@example.com", Password: "secret", Plan: "pro", internal: "x"}
+
+	sql, args, err := ExecuteE(`INSERT INTO accounts ({{ columns .Row }}) VALUES ({{ bindStruct .Row }})`, map[string]any{"Row": row})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `INSERT INTO accounts (created_by, id, email, Plan) VALUES ($1, $2, $3, $4)`; sql != want {
+		t.Errorf("got %q, want %q", sql, want)
+	}
+	if want := []any{"ops", 7, "ada@example.com", "pro"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got args %v, want %v", args, want)
+	}
+}
@@ -0,0 +1,208 @@
+package engine
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+	"text/template/parse"
+)
+
+// BindSite is one bind made by a run. Index is the number of the placeholder
+// it rendered, counted in order for MySQL's ?, and Value the bound value.
+// Location is the template action that bound it, as name:line:column, and
+// Offset the byte offset of its placeholder in the final SQL, or -1 when the
+// placeholder did not survive into it, for instance inside a -- comment.
+type BindSite struct {
+	Index    int
+	Value    any
+	Location string
+	Offset   int
+}
+
+// WithTrackBindSites makes RunMeta record every bind with the template
+// location that made it, see Meta.Sites. The SQL, args and errors are the
+// same with or without it.
+func WithTrackBindSites(enabled bool) Option {
+	return func(engine *Engine) error {
+		engine.track = enabled
+		return nil
+	}
+}
+
+// siteSuffix separates a builtin's name from the number of its tracking alias.
+const siteSuffix = "__site"
+
+// untracked are the builtins that render other templates rather than bind,
+// whose binds are tracked inside those templates.
+var untracked = map[string]bool{"include": true, "scope": true, "sub": true}
+
+// trackSites renames every call of a builtin in the parsed templates to an
+// alias of its own, so the alias can tell the parser where the call is before
+// running the builtin. The aliases are registered by builtins, and execError
+// names the builtin again in error messages.
+func (engine *Engine) trackSites() {
+	reserved := engine.builtins()
+	engine.tracked = maps.Clone(engine.tracked)
+	if engine.tracked == nil {
+		engine.tracked = make(map[string]trackedCall)
+	}
+
+	for _, tmpl := range engine.tempalte.Templates() {
+		if tmpl.Tree == nil {
+			continue
+		}
+
+		tree := tmpl.Tree
+		walk(tree.Root, func(node parse.Node) {
+			calls(node, func(ident *parse.IdentifierNode) {
+				if _, ok := reserved[ident.Ident]; !ok || untracked[ident.Ident] {
+					return
+				}
+
+				location, _ := tree.ErrorContext(ident)
+				alias := fmt.Sprintf("%s%s%d", ident.Ident, siteSuffix, len(engine.tracked))
+				engine.tracked[alias] = trackedCall{fn: ident.Ident, location: location}
+				ident.Ident = alias
+			})
+		})
+	}
+
+	engine.tempalte.Funcs(engine.builtins())
+}
+
+type trackedCall struct {
+	fn       string
+	location string
+}
+
+// calls visits the function identifiers in the pipelines of node, including
+// parenthesized ones.
+func calls(node parse.Node, visit func(*parse.IdentifierNode)) {
+	commands(node, func(cmd *parse.CommandNode) {
+		for _, arg := range cmd.Args {
+			if ident, ok := arg.(*parse.IdentifierNode); ok {
+				visit(ident)
+			}
+		}
+	})
+}
+
+// commands visits the commands in the pipelines of node, including those of
+// parenthesized pipelines.
+func commands(node parse.Node, visit func(*parse.CommandNode)) {
+	var pipe *parse.PipeNode
+	switch node := node.(type) {
+	case *parse.ActionNode:
+		pipe = node.Pipe
+	case *parse.TemplateNode:
+		pipe = node.Pipe
+	case *parse.IfNode:
+		pipe = node.Pipe
+	case *parse.RangeNode:
+		pipe = node.Pipe
+	case *parse.WithNode:
+		pipe = node.Pipe
+	case *parse.PipeNode:
+		pipe = node
+	}
+
+	if pipe == nil {
+		return
+	}
+
+	for _, cmd := range pipe.Cmds {
+		visit(cmd)
+		for _, arg := range cmd.Args {
+			if nested, ok := arg.(*parse.PipeNode); ok {
+				commands(nested, visit)
+			}
+		}
+	}
+}
+
+// traced wraps fn so every call first records location as the parser's
+// current bind site.
+func traced(parser *SqlParser, location string, fn any) any {
+	value := reflect.ValueOf(fn)
+	return reflect.MakeFunc(value.Type(), func(in []reflect.Value) []reflect.Value {
+		parser.location = location
+		if value.Type().IsVariadic() {
+			return value.CallSlice(in)
+		}
+		return value.Call(in)
+	}).Interface()
+}
+
+// placeSites sets the Offset of every site to that of its placeholder in sql,
+// comments included. Sites and placeholders come in the same order; MySQL's ?
+// are numbered by position, so those are matched by order alone. Only a
+// deduped placeholder whose first use was dropped with a stripped comment can
+// be placed at a later use.
+func placeSites(sites []BindSite, sql string, dialect Dialect) {
+	type placeholder struct{ index, offset int }
+	var found []placeholder
+	scanPlaceholders(sql, dialect, true, func(index, offset int) {
+		found = append(found, placeholder{index, offset})
+	})
+
+	next := 0
+	for i := range sites {
+		sites[i].Offset = -1
+		if next < len(found) && (!dialect.numbered() || found[next].index == sites[i].Index) {
+			sites[i].Offset = found[next].offset
+			next++
+		}
+	}
+}
+
+// scanPlaceholders calls visit with the number and offset of every placeholder
+// of the dialect in sql, skipping quoted literals, and comments unless
+// comments is set.
+func scanPlaceholders(sql string, dialect Dialect, comments bool, visit func(index, offset int)) {
+	prefix := dialect.prefix()
+
+	offset, count := 0, 0
+	scanSQL(sql, func(text string, kind segment) {
+		scan := kind == plainText || comments && kind == commentText
+		for i := 0; scan && i < len(text); i++ {
+			if !strings.HasPrefix(text[i:], prefix) || prefix == ":" && i > 0 && text[i-1] == ':' {
+				continue
+			}
+
+			if !dialect.numbered() {
+				count++
+				visit(count, offset+i)
+				continue
+			}
+
+			end, index := i+len(prefix), 0
+			for end < len(text) && text[end] >= '0' && text[end] <= '9' {
+				index = index*10 + int(text[end]-'0')
+				end++
+			}
+
+			if end > i+len(prefix) {
+				visit(index, offset+i)
+				i = end - 1
+			}
+		}
+
+		offset += len(text)
+	})
+}
+
+// CountPlaceholders counts the distinct placeholders of dialect in sql that a
+// database sees, ignoring anything inside quoted literals and comments. A
+// numbered placeholder reused by arg dedup counts once and every MySQL ?
+// counts. For SQL rendered by an engine of that dialect it equals the number
+// of bound args, unless a bind was rendered inside a comment: that arg has no
+// placeholder the database will see, which is the mismatch to look for.
+func CountPlaceholders(sql string, dialect Dialect) int {
+	seen := make(map[int]bool)
+	scanPlaceholders(sql, dialect, false, func(index, offset int) {
+		seen[index] = true
+	})
+
+	return len(seen)
+}
@@ -0,0 +1,173 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTrackBindSites(t *testing.T) {
+	query := "SELECT * FROM users\nWHERE id = {{ bind .ID }}\n  AND name = {{ bind .Name }} AND org_id = {{ .Org | bind }}"
+	data := map[string]any{"ID": 42, "Name": "ada", "Org": 7}
+
+	plain, err := Compile(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantSQL, wantArgs, err := plain.Run(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tracked, err := NewEngine(WithTrackBindSites(true)).Compile(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sql, args, meta, err := tracked.RunMeta(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sql != wantSQL || !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("tracking changed the output to %q %v, want %q %v", sql, args, wantSQL, wantArgs)
+	}
+
+	want := []BindSite{
+		{Index: 1, Value: 42, Location: "__rabbit__:2:14", Offset: 31},
+		{Index: 2, Value: "ada", Location: "__rabbit__:3:16", Offset: 47},
+		{Index: 3, Value: 7, Location: "__rabbit__:3:53", Offset: 63},
+	}
+	if !reflect.DeepEqual(meta.Sites, want) {
+		t.Errorf("got sites %+v, want %+v", meta.Sites, want)
+	}
+
+	_, _, meta, err = plain.RunMeta(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Sites != nil {
+		t.Errorf("untracked run recorded sites %+v", meta.Sites)
+	}
+}
+
+// fuzzFragments are the pieces FuzzPlaceholders builds templates from.
+var fuzzFragments = []string{
+	" AND a = {{ bind .A }}",
+	" AND b = {{ bind .B }}",
+	" AND c IN ({{ bindSlice .IDs }})",
+	" AND e IN ({{ bindSlice .Empty }})",
+	"{{ if .Flag }} AND f = {{ bind .A }}{{ end }}",
+	"{{ if not .Flag }} AND g = {{ bind .B }}{{ else }} AND g IS NULL{{ end }}",
+	"{{ range .IDs }} OR r = {{ bind . }}{{ end }}",
+	" AND note = '$1 ? @p1 :1 -- /*'",
+	" AND \"col?\" = 1",
+	" AND x::int = 1",
+	" /* ? $2 @p3 :4 */",
+	" AND h LIKE {{ likeContains .Term }}",
+	" AND (k, v) IN ({{ values .Rows \"k\" \"v\" }})",
+	" AND {{ tuples .Rows \"k\" \"v\" }}",
+	" AND n = {{ bind .Nil }}",
+	" -- {{ bind .B }}\n",
+}
+
+// commentFragment binds inside a comment, where the database never sees the
+// placeholder; decoyFragment is a comment holding placeholder lookalikes.
+const (
+	decoyFragment   = 10
+	commentFragment = 15
+)
+
+func TestTrackBindSitesErrors(t *testing.T) {
+	tmpl, err := NewEngine(WithTrackBindSites(true)).Compile("UPDATE events SET payload = {{ jsonb .Payload }} WHERE id = {{ bind .ID }}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, _, err = tmpl.RunMeta(map[string]any{"Payload": make(chan int), "ID": 1})
+	if err == nil {
+		t.Fatal("an unmarshalable payload did not fail")
+	}
+	if message := err.Error(); strings.Contains(message, "__site") || !strings.Contains(message, "error calling jsonb") {
+		t.Errorf("got %q, want the error to name jsonb", message)
+	}
+
+	var unsupported *json.UnsupportedTypeError
+	if !errors.As(err, &unsupported) {
+		t.Errorf("got %v, want it to wrap a *json.UnsupportedTypeError", err)
+	}
+}
+
+func FuzzPlaceholders(f *testing.F) {
+	seeds := []struct {
+		fragments []byte
+		dialect   Dialect
+		dedup     bool
+	}{
+		{[]byte{3}, Postgres, false},            // empty bindSlice
+		{[]byte{0, 0, 4, 1}, Postgres, true},    // dedup
+		{[]byte{0, 0, 4, 1}, SQLServer, true},   // dedup
+		{[]byte{2, 6, 12}, Postgres, false},     // bindSlice and values expansion
+		{[]byte{2, 6, 12, 13}, MySQL, false},    // bindSlice and values expansion
+		{[]byte{12, 13, 2}, SQLServer, false},   // values and tuples expansion
+		{[]byte{15, 1}, Postgres, false},        // bind inside a comment
+		{[]byte{15, 1}, Postgres, true},         // bind inside a comment, deduped
+		{[]byte{7, 0, 8, 10}, Postgres, false},  // quoted and commented $1
+		{[]byte{7, 0, 8, 9, 10}, Oracle, false}, // quoted :1 and x::int
+		{[]byte{7, 0, 8, 10}, MySQL, false},     // quoted and commented ?
+		{[]byte{11, 14, 5}, Oracle, true},
+	}
+	for _, seed := range seeds {
+		f.Add(seed.fragments, uint8(seed.dialect), seed.dedup, true)
+		f.Add(seed.fragments, uint8(seed.dialect), seed.dedup, false)
+	}
+
+	f.Fuzz(func(t *testing.T, fragments []byte, dialect uint8, dedup, flag bool) {
+		query := new(strings.Builder)
+		query.WriteString("SELECT id FROM t WHERE 1 = 1")
+
+		commented, decoy := false, false
+		for _, fragment := range fragments {
+			index := int(fragment) % len(fuzzFragments)
+			commented = commented || index == commentFragment
+			decoy = decoy || index == decoyFragment
+			query.WriteString(fuzzFragments[index])
+		}
+
+		engine, err := NewEngineE(WithDialect(Dialect(dialect%4)), WithArgDedup(dedup))
+		if err != nil {
+			t.Skip(err)
+		}
+
+		sql, args, err := engine.Execute(query.String(), map[string]any{
+			"A":     7,
+			"B":     "it's",
+			"IDs":   []int{1, 2},
+			"Empty": []int{},
+			"Flag":  flag,
+			"Term":  "50%_off",
+			"Rows":  []map[string]any{{"k": 1, "v": "a"}, {"k": 2, "v": "b"}},
+			"Nil":   (*int)(nil),
+		})
+		if err != nil {
+			t.Fatalf("%q: %v", query, err)
+		}
+
+		// Every bound arg has a placeholder somewhere in the SQL.
+		seen := make(map[int]bool)
+		scanPlaceholders(sql, engine.parser.dialect, true, func(index, offset int) {
+			seen[index] = true
+		})
+		if !decoy && len(seen) != len(args) {
+			t.Fatalf("%q: %d placeholders, comments included, for %d args", sql, len(seen), len(args))
+		}
+
+		// Outside comments, which the database ignores, the count matches
+		// the args unless a bind was rendered into a comment.
+		count := CountPlaceholders(sql, engine.parser.dialect)
+		if !commented && count != len(args) || count > len(args) {
+			t.Fatalf("%q: %d placeholders for %d args", sql, count, len(args))
+		}
+	})
+}
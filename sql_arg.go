@@ -1,10 +1,27 @@
 package engine
 
-import "fmt"
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
 
 type SqlParser struct {
-	args  []any
-	count int
+	args     []any
+	count    int
+	dialect  Dialect
+	data     any
+	dedup    bool
+	seen     map[any]int
+	inline   bool
+	encode   func(time.Time) any
+	err      error
+	named    map[string]any
+	skip     int
+	sites    *[]BindSite
+	location string
 }
 
 func NewSqlParser() *SqlParser {
@@ -15,8 +32,169 @@ func NewSqlParser() *SqlParser {
 }
 
 func (parser *SqlParser) Parse(arg any) string {
+	arg = parser.bindable(arg)
+
+	if parser.named != nil && parser.err == nil {
+		parser.err = fmt.Errorf("positional placeholders are not supported by ExecuteNamed, use bind \"name\" value")
+	}
+
+	if parser.inline {
+		value, err := valueOf(arg)
+		if err != nil && parser.err == nil {
+			parser.err = err
+		}
+
+		parser.args = append(parser.args, value)
+		parser.count += 1
+
+		return literal(value, parser.dialect)
+	}
+
+	dedup := parser.dedup && arg != nil && reflect.ValueOf(arg).Comparable()
+	if dedup {
+		if index, ok := parser.seen[arg]; ok {
+			parser.recordSite(index, arg)
+			return parser.dialect.placeholder(index)
+		}
+	}
+
 	parser.args = append(parser.args, arg)
 	parser.count += 1
 
-	return fmt.Sprintf("$%d", parser.count)
+	if dedup {
+		parser.seen[arg] = parser.count
+	}
+
+	parser.recordSite(parser.count, arg)
+	return parser.dialect.placeholder(parser.count)
+}
+
+// recordSite records a bind site when the run collects them.
+func (parser *SqlParser) recordSite(index int, arg any) {
+	if parser.sites != nil {
+		*parser.sites = append(*parser.sites, BindSite{Index: index, Value: arg, Location: parser.location})
+	}
+}
+
+// ParseSlice binds every element of a slice or array as its own argument.
+// An empty slice renders NULL, so `IN ({{ bindSlice .IDs }})` matches nothing
+// instead of producing invalid SQL.
+func (parser *SqlParser) ParseSlice(arg any) (string, error) {
+	value := reflect.ValueOf(arg)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return "", fmt.Errorf("expected slice or array, got %T", arg)
+	}
+
+	if value.Len() == 0 {
+		return "NULL", nil
+	}
+
+	placeholders := make([]string, value.Len())
+	for i := range placeholders {
+		placeholders[i] = parser.Parse(value.Index(i).Interface())
+	}
+
+	return strings.Join(placeholders, ", "), nil
+}
+
+// ParseNamed binds the value stored under name in the map passed to Run. Each
+// reference binds again, so a name used twice yields two placeholders, except
+// for ExecuteNamed, where the value is bound under its name.
+func (parser *SqlParser) ParseNamed(name string) (string, error) {
+	data := reflect.ValueOf(parser.data)
+	if data.Kind() != reflect.Map || data.Type().Key().Kind() != reflect.String {
+		return "", fmt.Errorf("named arguments need a map with string keys, got %T", parser.data)
+	}
+
+	value := data.MapIndex(reflect.ValueOf(name).Convert(data.Type().Key()))
+	if !value.IsValid() {
+		return "", fmt.Errorf("no argument named %q", name)
+	}
+
+	if parser.named != nil {
+		return parser.bindName(name, value.Interface())
+	}
+
+	return parser.Parse(value.Interface()), nil
+}
+
+// bindable turns nil pointers, nil pointers to Valuers included, into an
+// untyped nil so drivers write NULL, and hands time.Time values, pointed to
+// or not, to the configured time encoder. Valuers such as sql.NullString and every other
+// value pass through as is.
+func (parser *SqlParser) bindable(arg any) any {
+	if value := reflect.ValueOf(arg); value.Kind() == reflect.Pointer && value.IsNil() {
+		return nil
+	}
+
+	if ptr, ok := arg.(*time.Time); ok {
+		arg = *ptr
+	}
+
+	switch value := arg.(type) {
+	case driver.Valuer:
+		return arg
+	case time.Time:
+		if parser.encode != nil {
+			return parser.encode(value)
+		}
+	}
+
+	return arg
+}
+
+// valueOf resolves a driver.Valuer to the value the driver would bind, for
+// the paths that need the concrete value rather than the Valuer itself. A nil
+// pointer Valuer is NULL, as it is for database/sql.
+func valueOf(arg any) (any, error) {
+	valuer, ok := arg.(driver.Valuer)
+	if !ok {
+		return arg, nil
+	}
+
+	if value := reflect.ValueOf(arg); value.Kind() == reflect.Pointer && value.IsNil() {
+		return nil, nil
+	}
+
+	value, err := valuer.Value()
+	if err != nil {
+		return nil, fmt.Errorf("value of %T: %w", arg, err)
+	}
+
+	return value, nil
+}
+
+func (parser *SqlParser) fork() *SqlParser {
+	forked := NewSqlParser()
+	forked.dialect = parser.dialect
+	forked.dedup = parser.dedup
+	forked.encode = parser.encode
+	forked.skip = parser.skip
+	forked.count = parser.skip
+	forked.seen = make(map[any]int)
+
+	return forked
+}
+
+// recycle empties the parser for another execution configured like base,
+// keeping the memory of its args slice and dedup map.
+func (parser *SqlParser) recycle(base *SqlParser) {
+	clear(parser.args)
+	clear(parser.seen)
+
+	args, seen := parser.args[:0], parser.seen
+	*parser = *base
+	parser.args, parser.seen, parser.count = args, seen, base.skip
+}
+
+func (parser *SqlParser) validate() error {
+	if parser.dedup && !parser.dialect.numbered() {
+		return fmt.Errorf("engine: argument dedup needs numbered placeholders, %s uses positional ones", parser.dialect)
+	}
+
+	if parser.skip > 0 && !parser.dialect.numbered() {
+		return fmt.Errorf("engine: a start index needs numbered placeholders, %s uses positional ones", parser.dialect)
+	}
+
+	return nil
 }
@@ -0,0 +1,118 @@
+package engine
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseNilAndNullValues(t *testing.T) {
+	var name *string
+	var nullName *sql.NullString
+	age := 36
+
+	tests := []struct {
+		name string
+		arg  any
+		want any
+	}{
+		{"nil pointer", name, nil},
+		{"nil pointer to Valuer", nullName, nil},
+		{"invalid NullInt64", sql.NullInt64{}, sql.NullInt64{}},
+		{"valid NullString", sql.NullString{String: "ada", Valid: true}, sql.NullString{String: "ada", Valid: true}},
+		{"value", 36, 36},
+		{"pointer", &age, &age},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parser := NewSqlParser()
+			if got := parser.Parse(test.arg); got != "$1" {
+				t.Errorf("got placeholder %q, want $1", got)
+			}
+			if got := parser.args[0]; !reflect.DeepEqual(got, test.want) {
+				t.Errorf("bound %#v, want %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSetOptionalSkipsNilValuer(t *testing.T) {
+	query, args, err := ExecuteE(`UPDATE t SET {{ setOptional "a" .A "b" .B }}`, map[string]any{
+		"A": (*sql.NullString)(nil),
+		"B": sql.NullString{String: "x", Valid: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `UPDATE t SET "b" = $1`; query != want {
+		t.Errorf("got %q, want %q", query, want)
+	}
+	if len(args) != 1 {
+		t.Errorf("got args %v, want one", args)
+	}
+}
+
+func TestParseSlice(t *testing.T) {
+	tests := []struct {
+		name     string
+		ids      any
+		wantSQL  string
+		wantArgs []any
+	}{
+		{"ints", []int{1, 2, 3}, "SELECT * FROM users WHERE id IN ($1, $2, $3)", []any{1, 2, 3}},
+		{"empty", []int{}, "SELECT * FROM users WHERE id IN (NULL)", []any{}},
+		{"strings", []string{"a", "b"}, "SELECT * FROM users WHERE id IN ($1, $2)", []any{"a", "b"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, args, err := ExecuteE("SELECT * FROM users WHERE id IN ({{ bindSlice .IDs }})", map[string]any{"IDs": test.ids})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != test.wantSQL {
+				t.Errorf("got %q, want %q", query, test.wantSQL)
+			}
+			if !reflect.DeepEqual(args, test.wantArgs) {
+				t.Errorf("got args %#v, want %#v", args, test.wantArgs)
+			}
+		})
+	}
+}
+
+var errBadValue = errors.New("bad value")
+
+type failingValuer struct{}
+
+func (failingValuer) Value() (driver.Value, error) {
+	return nil, errBadValue
+}
+
+func TestFailingValuer(t *testing.T) {
+	const query = "SELECT * FROM users WHERE id = {{ bind .ID }}"
+	args := map[string]any{"ID": failingValuer{}}
+
+	if _, err := ExecuteDebug(query, args); !errors.Is(err, errBadValue) {
+		t.Errorf("ExecuteDebug: got %v, want %v", err, errBadValue)
+	}
+
+	tmpl, err := Compile(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := tmpl.RunMeta(args); !errors.Is(err, errBadValue) {
+		t.Errorf("RunMeta: got %v, want %v", err, errBadValue)
+	}
+
+	_, bound, err := tmpl.Run(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []any{failingValuer{}}; !reflect.DeepEqual(bound, want) {
+		t.Errorf("Run: got args %v, want %v", bound, want)
+	}
+}
@@ -0,0 +1,90 @@
+package engine
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"text/template/parse"
+)
+
+type lexState int
+
+const (
+	inCode lexState = iota
+	inString
+	inLineComment
+	inBlockComment
+)
+
+// WithStrictBind rejects templates with an action inside a quoted SQL string,
+// as in '{{ .Name }}', where the value would be interpolated instead of bound.
+// The check runs when a template is parsed. It follows the text between
+// actions, so it cannot see quotes produced by actions themselves.
+func WithStrictBind(enabled bool) Option {
+	return func(engine *Engine) error {
+		engine.strict = enabled
+		return nil
+	}
+}
+
+func (engine *Engine) checkStrict() error {
+	templates := engine.tempalte.Templates()
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name() < templates[j].Name()
+	})
+
+	var errs []error
+	for _, tmpl := range templates {
+		if tmpl.Tree == nil {
+			continue
+		}
+
+		state := inCode
+		walk(tmpl.Tree.Root, func(node parse.Node) {
+			switch node := node.(type) {
+			case *parse.TextNode:
+				state = lex(state, string(node.Text))
+			case *parse.ActionNode, *parse.TemplateNode:
+				if state == inString {
+					errs = append(errs, nodeError(tmpl.Tree, node, "action inside a quoted SQL string, bind the value instead"))
+				}
+			}
+		})
+	}
+
+	return errors.Join(errs...)
+}
+
+// lex advances state over text, tracking single quoted strings and comments.
+func lex(state lexState, text string) lexState {
+	for i := 0; i < len(text); i++ {
+		switch state {
+		case inCode:
+			switch {
+			case text[i] == '\'':
+				state = inString
+			case strings.HasPrefix(text[i:], "--"):
+				state = inLineComment
+				i++
+			case strings.HasPrefix(text[i:], "/*"):
+				state = inBlockComment
+				i++
+			}
+		case inString:
+			if text[i] == '\'' {
+				state = inCode
+			}
+		case inLineComment:
+			if text[i] == '\n' {
+				state = inCode
+			}
+		case inBlockComment:
+			if strings.HasPrefix(text[i:], "*/") {
+				state = inCode
+				i++
+			}
+		}
+	}
+
+	return state
+}
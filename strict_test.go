@@ -0,0 +1,23 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStrictBind(t *testing.T) {
+	engine := NewEngine(WithStrictBind(true))
+
+	var parseErr *ParseError
+	if _, err := engine.Compile("SELECT * FROM users WHERE name = '{{ .Name }}'"); !errors.As(err, &parseErr) {
+		t.Errorf("quoted action: got %v, want a *ParseError", err)
+	}
+
+	sql, args, err := engine.Execute("SELECT * FROM users WHERE name = {{ bind .Name }} AND note = 'it''s -- fine'", map[string]any{"Name": "ada"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "SELECT * FROM users WHERE name = $1 AND note = 'it''s -- fine'"; sql != want || len(args) != 1 {
+		t.Errorf("got %q with args %v, want %q", sql, args, want)
+	}
+}
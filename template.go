@@ -1,21 +1,162 @@
 package engine
 
-import "text/template"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
+)
+
+const maxIncludeDepth = 100
+
+// defaultEngine serves the package level functions, so repeated calls share
+// its compiled templates and pooled runs.
+var defaultEngine = NewEngine()
+
+var errIncludeDepth = fmt.Errorf("templates nested more than %d levels deep", maxIncludeDepth)
 
 type Engine struct {
 	tempalte *template.Template
 	parser   *SqlParser
+	rewrites []func(string) string
+	observer func(ExecInfo)
+	depth    int
+	runs     sync.Pool
+	lookup   func(name string) (*Template, error)
+	strict   bool
+	shared   bool
+	track    bool
+	tracked  map[string]trackedCall
+	compiled sync.Map
+	cached   atomic.Int32
+}
+
+func NewEngine(opts ...Option) *Engine {
+	engine, err := NewEngineE(opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return engine
 }
 
-func NewEngine() *Engine {
+func NewEngineE(opts ...Option) (*Engine, error) {
 	parser := NewSqlParser()
-	tmpl := template.New("__rabbit__").Funcs(template.FuncMap{
-		"marshal":     marshal,
-		"__sql_arg__": parser.Parse,
-	})
 
-	return &Engine{
+	engine := &Engine{
+		tempalte: template.New("__rabbit__"),
+		parser:   parser,
+	}
+	engine.tempalte.Funcs(engine.builtins())
+
+	for _, opt := range opts {
+		if err := opt(engine); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := parser.validate(); err != nil {
+		return nil, err
+	}
+
+	return engine, nil
+}
+
+func (engine *Engine) builtins() template.FuncMap {
+	parser := engine.parser
+
+	funcs := template.FuncMap{
+		"marshal":      marshal,
+		"bind":         parser.bind,
+		"bindSlice":    parser.ParseSlice,
+		"arg":          parser.ParseNamed,
+		"any":          parser.Any,
+		"jsonb":        parser.JSONB,
+		"paginate":     parser.Paginate,
+		"ident":        parser.Ident,
+		"orderBy":      parser.OrderBy,
+		"onConflict":   parser.OnConflict,
+		"returning":    parser.Returning,
+		"set":          parser.Set,
+		"setOptional":  parser.SetOptional,
+		"boolLit":      parser.BoolLit,
+		"likePrefix":   parser.LikePrefix,
+		"likeContains": parser.LikeContains,
+		"values":       parser.Values,
+		"tuples":       parser.Tuples,
+		"columns":      parser.Columns,
+		"bindStruct":   parser.BindStruct,
+		"col":          parser.Col,
+		"coalesce":     parser.Coalesce,
+		"concat":       parser.Concat,
+		"greatest":     parser.Greatest,
+		"least":        parser.Least,
+		"include":      engine.include,
+		"scope":        engine.scope,
+		"sub":          engine.sub,
+		"__sql_arg__":  parser.Parse,
+	}
+
+	for alias, call := range engine.tracked {
+		funcs[alias] = traced(parser, call.location, funcs[call.fn])
+	}
+
+	return funcs
+}
+
+// fork clones the template and rebinds the builtins to a fresh parser, so
+// executions never share collected args.
+func (engine *Engine) fork() (*Engine, error) {
+	return engine.forkWith(engine.parser.fork())
+}
+
+// forkWith clones the template and rebinds the builtins to parser.
+func (engine *Engine) forkWith(parser *SqlParser) (*Engine, error) {
+	tmpl, err := engine.tempalte.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	forked := &Engine{
 		tempalte: tmpl,
 		parser:   parser,
+		rewrites: engine.rewrites,
+		observer: engine.observer,
+		lookup:   engine.lookup,
+		strict:   engine.strict,
+		track:    engine.track,
+		tracked:  engine.tracked,
+	}
+	tmpl.Funcs(forked.builtins())
+
+	return forked, nil
+}
+
+// include renders the named template inline. It binds into the same parser as
+// the including query, so args keep their order across fragments.
+func (engine *Engine) include(name string, data any) (string, error) {
+	if engine.depth >= maxIncludeDepth {
+		return "", errIncludeDepth
 	}
+
+	engine.depth++
+	defer func() { engine.depth-- }()
+
+	out := new(strings.Builder)
+	if err := engine.tempalte.ExecuteTemplate(out, name, data); err != nil {
+		if errors.Is(err, errIncludeDepth) {
+			return "", errIncludeDepth
+		}
+		return "", err
+	}
+
+	return out.String(), nil
+}
+
+// scope renders the fragment defined as "scope", the conventional home of the
+// tenant and soft-delete filters every query shares.
+func (engine *Engine) scope(data any) (string, error) {
+	return engine.include("scope", data)
 }
@@ -0,0 +1,36 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIncludeAndScope(t *testing.T) {
+	const fragments = `{{ define "scope" }}tenant_id = {{ bind . }} AND deleted_at IS NULL{{ end }}` +
+		`{{ define "active" }}active = {{ bind .Active }}{{ end }}`
+
+	tests := []struct {
+		name     string
+		query    string
+		wantSQL  string
+		wantArgs []any
+	}{
+		{"scope", `SELECT id FROM users WHERE {{ scope .TenantID }} AND id = {{ bind .ID }}`, `SELECT id FROM users WHERE tenant_id = $1 AND deleted_at IS NULL AND id = $2`, []any{3, 9}},
+		{"include", `SELECT id FROM users WHERE {{ include "active" . }} AND id = {{ bind .ID }}`, `SELECT id FROM users WHERE active = $1 AND id = $2`, []any{true, 9}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sql, args, err := ExecuteE(fragments+test.query, map[string]any{"TenantID": 3, "Active": true, "ID": 9})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sql != test.wantSQL {
+				t.Errorf("got %q, want %q", sql, test.wantSQL)
+			}
+			if !reflect.DeepEqual(args, test.wantArgs) {
+				t.Errorf("got args %v, want %v", args, test.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Tuples renders a composite key lookup, `(a, b) IN (($1, $2), ($3, $4))`,
+// for the named columns over rows, whose elements are read like those of
+// Values. SQL Server has no row value IN, so there the lookup is spelled
+// `(([a] = @p1 AND [b] = @p2) OR ...)`. No rows render 1 = 0, which matches
+// nothing.
+func (parser *SqlParser) Tuples(rows any, columns ...string) (string, error) {
+	list := reflect.ValueOf(rows)
+	if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+		return "", fmt.Errorf("expected slice or array, got %T", rows)
+	}
+
+	if len(columns) == 0 {
+		return "", fmt.Errorf("no columns to match")
+	}
+
+	quoted, err := parser.idents(columns)
+	if err != nil {
+		return "", err
+	}
+
+	if list.Len() == 0 {
+		return "1 = 0", nil
+	}
+
+	tuples := make([]string, list.Len())
+	for i := range tuples {
+		values, err := rowValues(list.Index(i), columns)
+		if err != nil {
+			return "", fmt.Errorf("row %d: %w", i, err)
+		}
+
+		placeholders := make([]string, len(values))
+		for j, value := range values {
+			placeholders[j] = parser.Parse(value)
+		}
+
+		if parser.dialect == SQLServer {
+			for j := range placeholders {
+				placeholders[j] = quoted[j] + " = " + placeholders[j]
+			}
+			tuples[i] = "(" + strings.Join(placeholders, " AND ") + ")"
+		} else {
+			tuples[i] = "(" + strings.Join(placeholders, ", ") + ")"
+		}
+	}
+
+	if parser.dialect == SQLServer {
+		return "(" + strings.Join(tuples, " OR ") + ")", nil
+	}
+
+	return "(" + strings.Join(quoted, ", ") + ") IN (" + strings.Join(tuples, ", ") + ")", nil
+}
@@ -0,0 +1,123 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template/parse"
+)
+
+// Validate parses query and checks it statically, without binding any args:
+// syntax errors, unknown functions and references to undefined templates are
+// all reported.
+func Validate(query string) error {
+	return defaultEngine.Validate(query)
+}
+
+func (engine *Engine) Validate(query string) error {
+	tmpl, err := engine.Compile(query)
+	if err != nil {
+		return err
+	}
+
+	return tmpl.engine.check()
+}
+
+// Validate checks every template in the set, see Validate. All problems are
+// joined into the returned error.
+func (set *TemplateSet) Validate() error {
+	return set.engine.check()
+}
+
+func (engine *Engine) check() error {
+	templates := engine.tempalte.Templates()
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name() < templates[j].Name()
+	})
+
+	var errs []error
+	for _, tmpl := range templates {
+		if tmpl.Tree == nil {
+			continue
+		}
+
+		walk(tmpl.Tree.Root, func(node parse.Node) {
+			for _, name := range references(node) {
+				if engine.tempalte.Lookup(name) == nil {
+					errs = append(errs, nodeError(tmpl.Tree, node, fmt.Sprintf("template %q not defined", name)))
+				}
+			}
+		})
+	}
+
+	return errors.Join(errs...)
+}
+
+// references lists the templates node refers to by a constant name, through
+// {{ template }}, include or scope.
+func references(node parse.Node) []string {
+	switch node := node.(type) {
+	case *parse.TemplateNode:
+		return []string{node.Name}
+	case *parse.ActionNode:
+		var names []string
+		for _, cmd := range node.Pipe.Cmds {
+			fn, ok := cmd.Args[0].(*parse.IdentifierNode)
+			if !ok {
+				continue
+			}
+
+			switch {
+			case fn.Ident == "scope":
+				names = append(names, "scope")
+			case fn.Ident == "include" && len(cmd.Args) > 1:
+				if name, ok := cmd.Args[1].(*parse.StringNode); ok {
+					names = append(names, name.Text)
+				}
+			}
+		}
+		return names
+	}
+
+	return nil
+}
+
+func nodeError(tree *parse.Tree, node parse.Node, message string) *ParseError {
+	location, _ := tree.ErrorContext(node)
+
+	parsed := &ParseError{Name: tree.ParseName, Err: fmt.Errorf("template: %s: %s", location, message)}
+	if parts := strings.Split(location, ":"); len(parts) >= 3 {
+		parsed.Line, _ = strconv.Atoi(parts[len(parts)-2])
+	}
+
+	return parsed
+}
+
+// walk visits node and everything nested in it in document order.
+func walk(node parse.Node, visit func(parse.Node)) {
+	visit(node)
+
+	switch node := node.(type) {
+	case *parse.ListNode:
+		for _, child := range node.Nodes {
+			walk(child, visit)
+		}
+	case *parse.IfNode:
+		walkBranch(&node.BranchNode, visit)
+	case *parse.RangeNode:
+		walkBranch(&node.BranchNode, visit)
+	case *parse.WithNode:
+		walkBranch(&node.BranchNode, visit)
+	}
+}
+
+func walkBranch(branch *parse.BranchNode, visit func(parse.Node)) {
+	if branch.List != nil {
+		walk(branch.List, visit)
+	}
+	if branch.ElseList != nil {
+		walk(branch.ElseList, visit)
+	}
+}
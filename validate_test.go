@@ -0,0 +1,35 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestValidate(t *testing.T) {
+	if err := Validate("SELECT * FROM users WHERE id = {{ bind .ID }}"); err != nil {
+		t.Errorf("valid template: %v", err)
+	}
+
+	var parseErr *ParseError
+	if err := Validate("SELECT * FROM users WHERE id = {{ if .ID }}{{ bind .ID }}"); !errors.As(err, &parseErr) {
+		t.Errorf("broken template: got %v, want a *ParseError", err)
+	}
+}
+
+func TestTemplateSetValidate(t *testing.T) {
+	set, err := ParseFS(fstest.MapFS{
+		"users.sql.tmpl": {Data: []byte("SELECT id FROM users\nWHERE {{ template \"tenant.sql.tmpl\" . }}")},
+	}, "*.sql.tmpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var parseErr *ParseError
+	if err := set.Validate(); !errors.As(err, &parseErr) {
+		t.Fatalf("got %v, want a *ParseError", err)
+	}
+	if parseErr.Name != "users.sql.tmpl" || parseErr.Line != 2 {
+		t.Errorf("got %s:%d, want users.sql.tmpl:2", parseErr.Name, parseErr.Line)
+	}
+}